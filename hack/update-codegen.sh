@@ -43,13 +43,13 @@ chmod +x ${KNATIVE_CODEGEN_PKG}/hack/generate-knative.sh
 #                  instead of the $GOPATH directly. For normal projects this can be dropped.
 ${CODEGEN_PKG}/generate-groups.sh "deepcopy,client,informer,lister" \
   knative.dev/networking/pkg/client knative.dev/networking/pkg/apis \
-  "networking:v1alpha1" \
+  "networking:v1alpha1,v1beta1" \
   --go-header-file ${REPO_ROOT_DIR}/hack/boilerplate/boilerplate.go.txt
 
 # Knative Injection
 ${KNATIVE_CODEGEN_PKG}/hack/generate-knative.sh "injection" \
   knative.dev/networking/pkg/client knative.dev/networking/pkg/apis \
-  "networking:v1alpha1" \
+  "networking:v1alpha1,v1beta1" \
   --go-header-file ${REPO_ROOT_DIR}/hack/boilerplate/boilerplate.go.txt
 
 # Generate our own client for istio (otherwise injection won't work)
@@ -34,12 +34,16 @@ const (
 	ProtocolHTTP1 ProtocolType = "http1"
 	// ProtocolH2C maps to HTTP/2 with Prior Knowledge.
 	ProtocolH2C ProtocolType = "h2c"
+	// ProtocolH2 maps to HTTP/2 over TLS.
+	ProtocolH2 ProtocolType = "h2"
+	// ProtocolGRPC maps to gRPC, which is carried over HTTP/2 with Prior Knowledge.
+	ProtocolGRPC ProtocolType = "grpc"
 )
 
 // Validate validates that ProtocolType has a correct enum value.
 func (p ProtocolType) Validate(context.Context) *apis.FieldError {
 	switch p {
-	case ProtocolH2C, ProtocolHTTP1:
+	case ProtocolH2C, ProtocolHTTP1, ProtocolH2, ProtocolGRPC:
 		return nil
 	case ProtocolType(""):
 		return apis.ErrMissingField(apis.CurrentField)
@@ -45,6 +45,14 @@ func TestProtocolTypeValidate(t *testing.T) {
 		name:   "valid http1 protocol",
 		proto:  ProtocolHTTP1,
 		expect: nil,
+	}, {
+		name:   "valid h2 protocol",
+		proto:  ProtocolH2,
+		expect: nil,
+	}, {
+		name:   "valid grpc protocol",
+		proto:  ProtocolGRPC,
+		expect: nil,
 	}}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
@@ -0,0 +1,31 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCertificateConversion(t *testing.T) {
+	source, sink := &Certificate{}, &Certificate{}
+	if err := source.ConvertTo(context.Background(), sink); err == nil {
+		t.Error("ConvertTo() = nil, wanted error")
+	}
+	if err := sink.ConvertFrom(context.Background(), source); err == nil {
+		t.Error("ConvertFrom() = nil, wanted error")
+	}
+}
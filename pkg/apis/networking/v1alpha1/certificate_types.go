@@ -55,6 +55,9 @@ var (
 	_ apis.Validatable = (*Certificate)(nil)
 	_ apis.Defaultable = (*Certificate)(nil)
 
+	// Check that Certificate can be converted to other versions.
+	_ apis.Convertible = (*Certificate)(nil)
+
 	// Check that we can create OwnerReferences to a Certificate..
 	_ kmeta.OwnerRefable = (*Certificate)(nil)
 
@@ -52,6 +52,9 @@ func (r *IngressRule) SetDefaults(ctx context.Context) {
 	if r.Visibility == "" {
 		r.Visibility = IngressVisibilityExternalIP
 	}
+	if r.Port != 0 && r.Protocol == "" {
+		r.Protocol = ListenerProtocolHTTP
+	}
 	r.HTTP.SetDefaults(ctx)
 }
 
@@ -68,6 +71,12 @@ func (p *HTTPIngressPath) SetDefaults(ctx context.Context) {
 	if len(p.Splits) == 1 && p.Splits[0].Percent == 0 {
 		p.Splits[0].Percent = 100
 	}
+	if p.ExtAuthz != nil && p.ExtAuthz.FailureMode == "" {
+		p.ExtAuthz.FailureMode = ExtAuthzFailureModeDeny
+	}
+	if p.Compression != nil && len(p.Compression.Algorithms) == 0 {
+		p.Compression.Algorithms = []CompressionAlgorithm{CompressionAlgorithmGzip}
+	}
 	// Deprecated, do not use.
 	p.DeprecatedRetries = nil
 }
@@ -130,6 +130,154 @@ func TestIngressDefaulting(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "listener-protocol-defaulting",
+		in: &Ingress{
+			Spec: IngressSpec{
+				Rules: []IngressRule{{
+					Visibility: IngressVisibilityExternalIP,
+					Port:       9000,
+					HTTP: &HTTPIngressRuleValue{
+						Paths: []HTTPIngressPath{{
+							Splits: []IngressBackendSplit{{
+								IngressBackend: IngressBackend{
+									ServiceName:      "revision-000",
+									ServiceNamespace: "default",
+									ServicePort:      intstr.FromInt(8080),
+								},
+								Percent: 100,
+							}},
+						}},
+					},
+				}},
+			},
+		},
+		want: &Ingress{
+			Spec: IngressSpec{
+				Rules: []IngressRule{{
+					Visibility: IngressVisibilityExternalIP,
+					Port:       9000,
+					// Protocol is filled in.
+					Protocol: ListenerProtocolHTTP,
+					HTTP: &HTTPIngressRuleValue{
+						Paths: []HTTPIngressPath{{
+							Splits: []IngressBackendSplit{{
+								IngressBackend: IngressBackend{
+									ServiceName:      "revision-000",
+									ServiceNamespace: "default",
+									ServicePort:      intstr.FromInt(8080),
+								},
+								Percent: 100,
+							}},
+						}},
+					},
+				}},
+			},
+		},
+	}, {
+		name: "ext-authz-failure-mode-defaulting",
+		in: &Ingress{
+			Spec: IngressSpec{
+				Rules: []IngressRule{{
+					Visibility: IngressVisibilityExternalIP,
+					HTTP: &HTTPIngressRuleValue{
+						Paths: []HTTPIngressPath{{
+							Splits: []IngressBackendSplit{{
+								IngressBackend: IngressBackend{
+									ServiceName:      "revision-000",
+									ServiceNamespace: "default",
+									ServicePort:      intstr.FromInt(8080),
+								},
+								Percent: 100,
+							}},
+							ExtAuthz: &ExtAuthz{
+								Service: IngressBackend{
+									ServiceName:      "authz",
+									ServiceNamespace: "default",
+									ServicePort:      intstr.FromInt(8080),
+								},
+							},
+						}},
+					},
+				}},
+			},
+		},
+		want: &Ingress{
+			Spec: IngressSpec{
+				Rules: []IngressRule{{
+					Visibility: IngressVisibilityExternalIP,
+					HTTP: &HTTPIngressRuleValue{
+						Paths: []HTTPIngressPath{{
+							Splits: []IngressBackendSplit{{
+								IngressBackend: IngressBackend{
+									ServiceName:      "revision-000",
+									ServiceNamespace: "default",
+									ServicePort:      intstr.FromInt(8080),
+								},
+								Percent: 100,
+							}},
+							ExtAuthz: &ExtAuthz{
+								Service: IngressBackend{
+									ServiceName:      "authz",
+									ServiceNamespace: "default",
+									ServicePort:      intstr.FromInt(8080),
+								},
+								// FailureMode is filled in.
+								FailureMode: ExtAuthzFailureModeDeny,
+							},
+						}},
+					},
+				}},
+			},
+		},
+	}, {
+		name: "compression-algorithms-defaulting",
+		in: &Ingress{
+			Spec: IngressSpec{
+				Rules: []IngressRule{{
+					Visibility: IngressVisibilityExternalIP,
+					HTTP: &HTTPIngressRuleValue{
+						Paths: []HTTPIngressPath{{
+							Splits: []IngressBackendSplit{{
+								IngressBackend: IngressBackend{
+									ServiceName:      "revision-000",
+									ServiceNamespace: "default",
+									ServicePort:      intstr.FromInt(8080),
+								},
+								Percent: 100,
+							}},
+							Compression: &Compression{
+								MinimumSize: 1024,
+							},
+						}},
+					},
+				}},
+			},
+		},
+		want: &Ingress{
+			Spec: IngressSpec{
+				Rules: []IngressRule{{
+					Visibility: IngressVisibilityExternalIP,
+					HTTP: &HTTPIngressRuleValue{
+						Paths: []HTTPIngressPath{{
+							Splits: []IngressBackendSplit{{
+								IngressBackend: IngressBackend{
+									ServiceName:      "revision-000",
+									ServiceNamespace: "default",
+									ServicePort:      intstr.FromInt(8080),
+								},
+								Percent: 100,
+							}},
+							Compression: &Compression{
+								// Algorithms is filled in.
+								Algorithms:  []CompressionAlgorithm{CompressionAlgorithmGzip},
+								MinimumSize: 1024,
+							},
+						}},
+					},
+				}},
+			},
+		},
 	}}
 
 	for _, test := range tests {
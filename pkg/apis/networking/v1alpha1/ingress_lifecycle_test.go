@@ -16,6 +16,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -77,6 +78,13 @@ func TestIngressTypicalFlow(t *testing.T) {
 	apistest.CheckConditionOngoing(r, IngressConditionLoadBalancerReady, t)
 	apistest.CheckConditionOngoing(r, IngressConditionReady, t)
 
+	const message = "1 of 2 Pods pending"
+	r.MarkLoadBalancerNotReadyWithMessage(message)
+	apistest.CheckConditionOngoing(r, IngressConditionLoadBalancerReady, t)
+	if got := r.GetCondition(IngressConditionLoadBalancerReady).Message; got != message {
+		t.Errorf("LoadBalancerReady message = %q, want: %q", got, message)
+	}
+
 	r.MarkLoadBalancerFailed("some reason", "some message")
 	apistest.CheckConditionFailed(r, IngressConditionLoadBalancerReady, t)
 	apistest.CheckConditionFailed(r, IngressConditionLoadBalancerReady, t)
@@ -110,6 +118,142 @@ func TestIngressTypicalFlow(t *testing.T) {
 	apistest.CheckConditionOngoing(r, IngressConditionReady, t)
 }
 
+func TestIngressReadyWithoutInitializeConditions(t *testing.T) {
+	// Reconcilers setting the conditions directly, without initializing them.
+	r := &IngressStatus{}
+	r.MarkNetworkConfigured()
+	r.MarkLoadBalancerReady(
+		[]LoadBalancerIngressStatus{{DomainInternal: "gateway.default.svc"}},
+		[]LoadBalancerIngressStatus{{DomainInternal: "private.gateway.default.svc"}},
+	)
+	apistest.CheckConditionSucceeded(r, IngressConditionFeatureSupported, t)
+	apistest.CheckConditionSucceeded(r, IngressConditionReady, t)
+
+	// In any order, and with an unsupported feature marked first.
+	r = &IngressStatus{}
+	r.MarkUnsupportedFeature("spec.accessLog")
+	r.MarkLoadBalancerReady(nil, nil)
+	r.MarkNetworkConfigured()
+	apistest.CheckConditionFailed(r, IngressConditionFeatureSupported, t)
+	apistest.CheckConditionFailed(r, IngressConditionReady, t)
+}
+
+func TestIngressFeatureSupported(t *testing.T) {
+	r := &IngressStatus{}
+	r.InitializeConditions()
+
+	// Features are assumed to be supported until told otherwise.
+	apistest.CheckConditionSucceeded(r, IngressConditionFeatureSupported, t)
+
+	r.MarkNetworkConfigured()
+	r.MarkLoadBalancerReady(
+		[]LoadBalancerIngressStatus{{DomainInternal: "gateway.default.svc"}},
+		[]LoadBalancerIngressStatus{{DomainInternal: "private.gateway.default.svc"}},
+	)
+	apistest.CheckConditionSucceeded(r, IngressConditionReady, t)
+
+	r.MarkUnsupportedFeature("spec.rules[0].http.paths[0].rewriteHost", "spec.accessLog")
+	apistest.CheckConditionFailed(r, IngressConditionFeatureSupported, t)
+	apistest.CheckConditionFailed(r, IngressConditionReady, t)
+	c := r.GetCondition(IngressConditionFeatureSupported)
+	if got, want := c.Reason, IngressReasonFeatureNotSupported; got != want {
+		t.Errorf("Reason = %q, wanted %q", got, want)
+	}
+	if got, want := c.Message, "The following fields are not supported by the Ingress implementation: "+
+		"spec.rules[0].http.paths[0].rewriteHost, spec.accessLog"; got != want {
+		t.Errorf("Message = %q, wanted %q", got, want)
+	}
+
+	// Initializing the conditions again keeps the failure.
+	r.InitializeConditions()
+	apistest.CheckConditionFailed(r, IngressConditionFeatureSupported, t)
+
+	r.MarkFeaturesSupported()
+	apistest.CheckConditionSucceeded(r, IngressConditionFeatureSupported, t)
+	apistest.CheckConditionSucceeded(r, IngressConditionReady, t)
+}
+
+func TestIngressRuleStatus(t *testing.T) {
+	r := &IngressStatus{}
+	r.InitializeRules(1, "foo.example.com", "bar.example.com", "foo.example.com")
+	r.ObservedGeneration = 1
+
+	want := []IngressRuleStatus{{
+		Host:  "bar.example.com",
+		Ready: corev1.ConditionUnknown,
+	}, {
+		Host:  "foo.example.com",
+		Ready: corev1.ConditionUnknown,
+	}}
+	if diff := cmp.Diff(want, r.Rules); diff != "" {
+		t.Error("InitializeRules (-want, +got) =", diff)
+	}
+
+	// Then a probe of foo fails.
+	r.MarkRuleNotReady("foo.example.com", errors.New("unexpected status code: want 200, got 503"))
+	// And bar becomes ready.
+	url := &apis.URL{Scheme: "http", Host: "bar.example.com"}
+	lb := &LoadBalancerIngressStatus{DomainInternal: "gateway.default.svc"}
+	r.MarkRuleReady("bar.example.com", url, lb)
+	// And a host we didn't know about shows up.
+	r.MarkRuleNotReady("baz.example.com", nil)
+
+	want = []IngressRuleStatus{{
+		Host:         "bar.example.com",
+		Ready:        corev1.ConditionTrue,
+		URL:          url,
+		LoadBalancer: lb,
+	}, {
+		Host:  "baz.example.com",
+		Ready: corev1.ConditionUnknown,
+	}, {
+		Host:           "foo.example.com",
+		Ready:          corev1.ConditionUnknown,
+		LastProbeError: "unexpected status code: want 200, got 503",
+	}}
+	if diff := cmp.Diff(want, r.Rules); diff != "" {
+		t.Error("Rules (-want, +got) =", diff)
+	}
+	if got := r.GetRuleStatus("baz.example.com"); got == nil || got.Ready != corev1.ConditionUnknown {
+		t.Errorf("GetRuleStatus(baz.example.com) = %v, wanted an Unknown status", got)
+	}
+
+	// Then foo becomes ready, which clears its probe error, and baz is removed.
+	r.MarkRuleReady("foo.example.com", url, lb)
+	r.InitializeRules(1, "foo.example.com", "bar.example.com")
+
+	want = []IngressRuleStatus{{
+		Host:         "bar.example.com",
+		Ready:        corev1.ConditionTrue,
+		URL:          url,
+		LoadBalancer: lb,
+	}, {
+		Host:         "foo.example.com",
+		Ready:        corev1.ConditionTrue,
+		URL:          url,
+		LoadBalancer: lb,
+	}}
+	if diff := cmp.Diff(want, r.Rules); diff != "" {
+		t.Error("Rules (-want, +got) =", diff)
+	}
+	if got := r.GetRuleStatus("baz.example.com"); got != nil {
+		t.Errorf("GetRuleStatus(baz.example.com) = %v, wanted nil", got)
+	}
+
+	// Then the spec changes, and foo isn't served according to it yet.
+	r.InitializeRules(2, "foo.example.com")
+
+	want = []IngressRuleStatus{{
+		Host:         "foo.example.com",
+		Ready:        corev1.ConditionUnknown,
+		URL:          url,
+		LoadBalancer: lb,
+	}}
+	if diff := cmp.Diff(want, r.Rules); diff != "" {
+		t.Error("Rules (-want, +got) =", diff)
+	}
+}
+
 func TestIngressGetCondition(t *testing.T) {
 	ingressStatus := &IngressStatus{}
 	ingressStatus.InitializeConditions()
@@ -17,8 +17,10 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/networking/pkg/apis/networking"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
@@ -58,6 +60,9 @@ var (
 	_ apis.Validatable = (*Ingress)(nil)
 	_ apis.Defaultable = (*Ingress)(nil)
 
+	// Check that Ingress can be converted to other versions.
+	_ apis.Convertible = (*Ingress)(nil)
+
 	// Check that we can create OwnerReferences to a Ingress.
 	_ kmeta.OwnerRefable = (*Ingress)(nil)
 
@@ -118,6 +123,49 @@ type IngressSpec struct {
 	//
 	// +optional
 	DeprecatedVisibility IngressVisibility `json:"visibility,omitempty"`
+
+	// AccessLog configures logging of the requests served by this Ingress.
+	// Unset fields take their cluster-wide defaults from the `config-network`
+	// ConfigMap.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	AccessLog *AccessLog `json:"accessLog,omitempty"`
+
+	// DefaultBackend is the backend receiving requests for any of the hosts of
+	// this Ingress whose path matches none of the HTTPIngressPaths of the
+	// matching rule, and which that rule's own DefaultBackend doesn't cover.
+	// It does not make the Ingress receive requests for hosts not listed in
+	// its rules.  When no default backend applies, such requests get a 404.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	DefaultBackend *IngressBackend `json:"defaultBackend,omitempty"`
+}
+
+// AccessLog describes how the requests served by an Ingress are logged.
+type AccessLog struct {
+	// Enabled specifies whether requests are logged.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Format is the golang text template used to format access log entries.
+	// Valid variables defined in the template include Host, Path, Status,
+	// Latency, SplitTarget, Tag, RequestHeaders and ResponseHeaders.
+	// +optional
+	Format string `json:"format,omitempty"`
+
+	// RequestHeaders is the list of request headers captured in access log
+	// entries, available to Format through `RequestHeaders`.
+	// +optional
+	RequestHeaders []string `json:"requestHeaders,omitempty"`
+
+	// ResponseHeaders is the list of response headers captured in access log
+	// entries, available to Format through `ResponseHeaders`.
+	// +optional
+	ResponseHeaders []string `json:"responseHeaders,omitempty"`
 }
 
 // IngressVisibility describes whether the Ingress should be exposed to
@@ -183,11 +231,87 @@ type IngressRule struct {
 	// specified then it defaults to `ExternalIP`.
 	Visibility IngressVisibility `json:"visibility,omitempty"`
 
+	// AllowedSourceRanges is a list of CIDRs. If it is not empty, only
+	// requests whose source address falls within one of the ranges are
+	// admitted, all others are rejected with a 403.
+	//
+	// The source address is the address of the peer connected to the gateway,
+	// unless the gateway is configured to trust `X-Forwarded-For` from the
+	// load balancer in front of it, in which case it is the client address
+	// reported by that load balancer. `X-Forwarded-For` sent by clients is
+	// never trusted. For `ClusterLocal` rules the source address is the
+	// in-cluster address of the caller, e.g. its Pod IP.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	AllowedSourceRanges []string `json:"allowedSourceRanges,omitempty"`
+
+	// DeniedSourceRanges is a list of CIDRs. Requests whose source address
+	// falls within one of the ranges are rejected with a 403, even if they
+	// are also within AllowedSourceRanges.
+	//
+	// The source address is determined as for AllowedSourceRanges.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	DeniedSourceRanges []string `json:"deniedSourceRanges,omitempty"`
+
+	// ErrorResponses replaces the body, and optionally the status code, of
+	// error responses sent for the hosts of this rule. This covers both
+	// errors returned by the backends and errors generated by the Ingress
+	// itself, e.g. when no backend is available. A status code may appear
+	// in at most one entry.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	ErrorResponses []ErrorResponse `json:"errorResponses,omitempty"`
+
+	// Port is the port on which the hosts of this rule are served, instead
+	// of the standard ports of the Ingress (80 for HTTP, 443 for HTTPS).
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// Protocol is the protocol clients use to connect to Port.  HTTPS
+	// requires the hosts of this rule to be covered by the TLS settings of
+	// the Ingress.  It may only be set along with Port, and defaults to HTTP.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	Protocol ListenerProtocol `json:"protocol,omitempty"`
+
+	// DefaultBackend is the backend receiving requests for the hosts of this
+	// rule whose path matches none of its HTTPIngressPaths.  It takes
+	// precedence over the DefaultBackend of the IngressSpec.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	DefaultBackend *IngressBackend `json:"defaultBackend,omitempty"`
+
 	// HTTP represents a rule to apply against incoming requests. If the
 	// rule is satisfied, the request is routed to the specified backend.
 	HTTP *HTTPIngressRuleValue `json:"http,omitempty"`
 }
 
+// ListenerProtocol is the protocol clients use to connect to the port on
+// which an IngressRule is served.
+type ListenerProtocol string
+
+const (
+	// ListenerProtocolHTTP serves plaintext HTTP.
+	ListenerProtocolHTTP ListenerProtocol = "HTTP"
+
+	// ListenerProtocolHTTPS serves HTTP over TLS.
+	ListenerProtocolHTTPS ListenerProtocol = "HTTPS"
+)
+
 // HTTPIngressRuleValue is a list of http selectors pointing to backends.
 // In the example: http://<host>/<path>?<searchpart> -> backend where
 // where parts of the url correspond to RFC 3986, this resource will be used
@@ -196,7 +320,10 @@ type IngressRule struct {
 type HTTPIngressRuleValue struct {
 	// A collection of paths that map requests to backends.
 	//
-	// If they are multiple matching paths, the first match takes precendent.
+	// Paths are evaluated in order of decreasing Priority, and paths of equal
+	// Priority in the order they are declared.  If there are multiple
+	// matching paths, the first one evaluated takes precedence, regardless
+	// of the length of the match.
 	Paths []HTTPIngressPath `json:"paths"`
 
 	// TODO: Consider adding fields for ingress-type specific global
@@ -216,6 +343,12 @@ type HTTPIngressPath struct {
 	// +optional
 	Path string `json:"path,omitempty"`
 
+	// Priority orders the evaluation of the paths of a rule: paths with a
+	// higher Priority are evaluated first.  Paths of equal Priority are
+	// evaluated in the order they are declared.  Defaults to 0.
+	// +optional
+	Priority int `json:"priority,omitempty"`
+
 	// RewriteHost rewrites the incoming request's host header.
 	//
 	// This field is currently experimental and not supported by all Ingress
@@ -253,6 +386,194 @@ type HTTPIngressPath struct {
 	// Retry in Kingress is not used anymore. See https://github.com/knative/serving/issues/6549
 	// +optional
 	DeprecatedRetries *HTTPRetry `json:"retries,omitempty"`
+
+	// JWT requires requests matching this path to carry a valid JSON Web
+	// Token. Requests without one are rejected with a 401.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	JWT *JWTAuthentication `json:"jwt,omitempty"`
+
+	// ExtAuthz delegates the decision whether to allow requests matching this
+	// path to an external authorization service.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	ExtAuthz *ExtAuthz `json:"extAuthz,omitempty"`
+
+	// Compression configures compression of the responses to requests
+	// matching this path. Responses are only compressed if the client
+	// accepts one of the configured algorithms through `Accept-Encoding`
+	// and the backend did not compress them already.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	Compression *Compression `json:"compression,omitempty"`
+}
+
+// Compression describes how responses are compressed by the Ingress.
+type Compression struct {
+	// Algorithms is the list of compression algorithms offered, in order
+	// of preference. Defaults to `gzip`.
+	// +optional
+	Algorithms []CompressionAlgorithm `json:"algorithms,omitempty"`
+
+	// MinimumSize is the minimum size of a response body in bytes for it
+	// to be compressed.
+	// +optional
+	MinimumSize int64 `json:"minimumSize,omitempty"`
+
+	// ContentTypes is the list of media types, e.g. `text/html`, of the
+	// responses that are compressed. If empty, the Ingress implementation
+	// picks a set of common textual media types.
+	// +optional
+	ContentTypes []string `json:"contentTypes,omitempty"`
+}
+
+// CompressionAlgorithm is an enumeration of the supported compression algorithms.
+type CompressionAlgorithm string
+
+const (
+	// CompressionAlgorithmGzip maps to gzip (RFC 1952).
+	CompressionAlgorithmGzip CompressionAlgorithm = "gzip"
+	// CompressionAlgorithmBrotli maps to Brotli (RFC 7932).
+	CompressionAlgorithmBrotli CompressionAlgorithm = "br"
+)
+
+// JWTAuthentication describes how requests are authenticated with JSON Web Tokens.
+type JWTAuthentication struct {
+	// Issuers is the list of accepted token issuers, matched against the
+	// `iss` claim of the token.
+	Issuers []string `json:"issuers"`
+
+	// Audiences is the list of accepted token audiences, matched against the
+	// `aud` claim of the token. If empty, the audience is not checked.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+
+	// JWKS is the JSON Web Key Set used to verify the token signature.
+	JWKS JWKSSource `json:"jwks"`
+
+	// FromHeader is the name of the header the token is read from. A
+	// `Bearer ` prefix on the header value is stripped.
+	// If neither FromHeader nor FromCookie is set, the token is read from
+	// the `Authorization` header.
+	// +optional
+	FromHeader string `json:"fromHeader,omitempty"`
+
+	// FromCookie is the name of the cookie the token is read from.
+	// +optional
+	FromCookie string `json:"fromCookie,omitempty"`
+
+	// ForwardClaims is a map from a claim name to the name of a header which
+	// is set to the value of that claim before forwarding the request to the
+	// backend.
+	// +optional
+	ForwardClaims map[string]string `json:"forwardClaims,omitempty"`
+}
+
+// JWKSSource describes where a JSON Web Key Set is read from.
+// Exactly one of the fields must be set.
+type JWKSSource struct {
+	// Inline is the JSON Web Key Set document itself.
+	// +optional
+	Inline string `json:"inline,omitempty"`
+
+	// SecretRef references the Secret key holding the JSON Web Key Set document.
+	// +optional
+	SecretRef *SecretKeyReference `json:"secretRef,omitempty"`
+}
+
+// ExtAuthz describes an external authorization service which is consulted
+// before a request is forwarded to its backend. The service receives the
+// request metadata and allows the request by responding with a 2xx status,
+// any other status denies it and is returned to the client.
+type ExtAuthz struct {
+	// Service is the authorization service. Its Protocol selects whether the
+	// check is made over HTTP (the default) or gRPC.
+	Service IngressBackend `json:"service"`
+
+	// RequestHeaders is the list of headers of the incoming request that are
+	// sent to the authorization service.
+	// +optional
+	RequestHeaders []string `json:"requestHeaders,omitempty"`
+
+	// UpstreamHeaders is the list of headers of an allowing authorization
+	// response that are added to the request before forwarding it to the
+	// backend.
+	// +optional
+	UpstreamHeaders []string `json:"upstreamHeaders,omitempty"`
+
+	// Timeout for the authorization check.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// FailureMode decides what happens to the request if the authorization
+	// service cannot be reached or times out. Defaults to `Deny`.
+	// +optional
+	FailureMode ExtAuthzFailureMode `json:"failureMode,omitempty"`
+}
+
+// ExtAuthzFailureMode describes how requests are handled when the external
+// authorization service fails.
+type ExtAuthzFailureMode string
+
+const (
+	// ExtAuthzFailureModeDeny rejects the request when the authorization
+	// service fails. This is the default value for ExtAuthzFailureMode.
+	ExtAuthzFailureModeDeny ExtAuthzFailureMode = "Deny"
+	// ExtAuthzFailureModeAllow forwards the request to the backend when the
+	// authorization service fails.
+	ExtAuthzFailureModeAllow ExtAuthzFailureMode = "Allow"
+)
+
+// ErrorResponse describes the response sent instead of an error response
+// with one of the given status codes.
+type ErrorResponse struct {
+	// StatusCodes are the status codes of the error responses to replace.
+	// They must be in the 4xx or 5xx range.
+	StatusCodes []int `json:"statusCodes"`
+
+	// Body references the ConfigMap key whose value is sent as the body of
+	// the response. Changes to the ConfigMap's data do not change the Ingress
+	// and so are not reflected in its status.
+	Body ConfigMapKeyReference `json:"body"`
+
+	// ContentType is the media type of Body. Defaults to text/html.
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+
+	// StatusCode is the status code sent instead of the original one. When
+	// unset, the original status code is kept.
+	// +optional
+	StatusCode int `json:"statusCode,omitempty"`
+}
+
+// ConfigMapKeyReference references a key of a ConfigMap.
+type ConfigMapKeyReference struct {
+	// ConfigMapName is the name of the referenced ConfigMap.
+	ConfigMapName string `json:"configMapName"`
+
+	// ConfigMapNamespace is the namespace of the referenced ConfigMap.
+	ConfigMapNamespace string `json:"configMapNamespace"`
+
+	// Key is the key within the ConfigMap's data.
+	Key string `json:"key"`
+}
+
+// SecretKeyReference references a key of a Secret.
+type SecretKeyReference struct {
+	// SecretName is the name of the referenced Secret.
+	SecretName string `json:"secretName"`
+
+	// SecretNamespace is the namespace of the referenced Secret.
+	SecretNamespace string `json:"secretNamespace"`
+
+	// Key is the key within the Secret's data.
+	Key string `json:"key"`
 }
 
 // IngressBackendSplit describes all endpoints for a given service and port.
@@ -272,6 +593,52 @@ type IngressBackendSplit struct {
 	// NOTE: This differs from K8s Ingress which doesn't allow header appending.
 	// +optional
 	AppendHeaders map[string]string `json:"appendHeaders,omitempty"`
+
+	// Locality configures how the requests for this split are spread across
+	// the zones of its endpoints.  When unset, requests are spread evenly
+	// across the healthy endpoints, regardless of their zone.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	Locality *LocalityPreferences `json:"locality,omitempty"`
+}
+
+// LocalityPreferences describes how requests are spread across the zones of
+// the endpoints of a backend, depending on the zone of the Ingress instance
+// receiving them.  Zones are the values of the `topology.kubernetes.io/zone`
+// label of the Nodes.  Distribution may not be combined with the other
+// preferences.
+type LocalityPreferences struct {
+	// PreferSameZone sends requests to the endpoints in the zone of the
+	// Ingress instance receiving them, as long as that zone has healthy
+	// endpoints.
+	// +optional
+	PreferSameZone bool `json:"preferSameZone,omitempty"`
+
+	// FailoverZones is the order in which other zones are tried when the
+	// zone of the Ingress instance has no healthy endpoints.  If none of them
+	// have healthy endpoints either, requests are spread evenly across the
+	// healthy endpoints of the remaining zones.  Requires PreferSameZone.
+	// +optional
+	FailoverZones []string `json:"failoverZones,omitempty"`
+
+	// Distribution overrides, per zone of the Ingress instance, the share of
+	// requests sent to each zone.  Zones without healthy endpoints are
+	// skipped, and their share spread over the other listed zones.
+	// +optional
+	Distribution []LocalityDistribution `json:"distribution,omitempty"`
+}
+
+// LocalityDistribution describes the share of requests received by the
+// Ingress instances of a zone that is sent to each zone.
+type LocalityDistribution struct {
+	// From is the zone of the Ingress instances receiving the requests.
+	From string `json:"from"`
+
+	// To maps zones to the percentage of the requests they receive.  The
+	// percentages must total to 100.
+	To map[string]int `json:"to"`
 }
 
 // IngressBackend describes all endpoints for a given service and port.
@@ -286,6 +653,14 @@ type IngressBackend struct {
 
 	// Specifies the port of the referenced service.
 	ServicePort intstr.IntOrString `json:"servicePort"`
+
+	// Protocol is the application-layer protocol spoken by the referenced
+	// service port. If unspecified, implementations infer the protocol from
+	// the name of the service port, e.g. `http2` for h2c.
+	//
+	// NOTE: This differs from K8s Ingress which doesn't allow setting the protocol.
+	// +optional
+	Protocol networking.ProtocolType `json:"protocol,omitempty"`
 }
 
 // HTTPRetry is DEPRECATED. Retry is not used in KIngress.
@@ -313,6 +688,34 @@ type IngressStatus struct {
 	// PrivateLoadBalancer contains the current status of the load-balancer.
 	// +optional
 	PrivateLoadBalancer *LoadBalancerStatus `json:"privateLoadBalancer,omitempty"`
+
+	// Rules contains the status of each of the hosts of the rules of the
+	// Ingress, sorted by host.
+	// +optional
+	Rules []IngressRuleStatus `json:"rules,omitempty"`
+}
+
+// IngressRuleStatus describes the status of a host of a rule of an Ingress.
+type IngressRuleStatus struct {
+	// Host is the host this status is about.
+	Host string `json:"host"`
+
+	// Ready is True once the host is served according to the latest spec of
+	// the Ingress, and Unknown until then.
+	Ready corev1.ConditionStatus `json:"ready"`
+
+	// URL is the URL at which the host is served.
+	// +optional
+	URL *apis.URL `json:"url,omitempty"`
+
+	// LoadBalancer is the load balancer serving the host.
+	// +optional
+	LoadBalancer *LoadBalancerIngressStatus `json:"loadBalancer,omitempty"`
+
+	// LastProbeError is the error of the last failed probe of the host.  It is
+	// cleared once the host is ready.
+	// +optional
+	LastProbeError string `json:"lastProbeError,omitempty"`
 }
 
 // LoadBalancerStatus represents the status of a load-balancer.
@@ -363,8 +766,19 @@ const (
 
 	// IngressConditionLoadBalancerReady is set when the Ingress has a ready LoadBalancer.
 	IngressConditionLoadBalancerReady apis.ConditionType = "LoadBalancerReady"
+
+	// IngressConditionFeatureSupported is set when the Ingress implementation
+	// supports all the fields set on the Ingress.  It is false, with reason
+	// IngressReasonFeatureNotSupported, when the implementation would have to
+	// ignore some of them.
+	IngressConditionFeatureSupported apis.ConditionType = "FeatureSupported"
 )
 
+// IngressReasonFeatureNotSupported is the reason of the
+// IngressConditionFeatureSupported condition when the Ingress implementation
+// does not support some of the fields set on the Ingress.
+const IngressReasonFeatureNotSupported = "FeatureNotSupported"
+
 // GetStatus retrieves the status of the Ingress. Implements the KRShaped interface.
 func (t *Ingress) GetStatus() *duckv1.Status {
 	return &t.Status.Status
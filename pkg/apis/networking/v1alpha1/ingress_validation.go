@@ -18,17 +18,60 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
 	"strconv"
 
+	"golang.org/x/net/http/httpguts"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	network "knative.dev/networking/pkg"
+	"knative.dev/networking/pkg/apis/networking"
 	"knative.dev/pkg/apis"
 )
 
 // Validate inspects and validates Ingress object.
 func (i *Ingress) Validate(ctx context.Context) *apis.FieldError {
 	ctx = apis.WithinParent(ctx, i.ObjectMeta)
-	return i.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec")
+	return i.validateIngressClass(ctx).ViaField("metadata").Also(
+		i.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+}
+
+// installedIngressClassesKey is the context key for the names of the
+// IngressClasses installed in the cluster.
+type installedIngressClassesKey struct{}
+
+// WithInstalledIngressClasses returns a context in which an Ingress is only
+// valid if its ingress class annotation, when set, names one of the given
+// installed IngressClasses.  Without it the annotation is not checked.
+func WithInstalledIngressClasses(ctx context.Context, classes sets.String) context.Context {
+	return context.WithValue(ctx, installedIngressClassesKey{}, classes)
+}
+
+// validateIngressClass checks that the ingress class annotation names an
+// installed IngressClass.  Updates that don't change the annotation are
+// allowed, so that Ingresses of an uninstalled class can still be cleaned up.
+func (i *Ingress) validateIngressClass(ctx context.Context) *apis.FieldError {
+	classes, ok := ctx.Value(installedIngressClassesKey{}).(sets.String)
+	if !ok {
+		return nil
+	}
+	class, ok := i.Annotations[networking.IngressClassAnnotationKey]
+	if !ok || classes.Has(class) {
+		return nil
+	}
+	if apis.IsInUpdate(ctx) {
+		if old, ok := apis.GetBaseline(ctx).(*Ingress); ok && old.Annotations[networking.IngressClassAnnotationKey] == class {
+			return nil
+		}
+	}
+	return &apis.FieldError{
+		Message: fmt.Sprintf("ingress class %q is not installed", class),
+		Paths:   []string{fmt.Sprintf("annotations[%s]", networking.IngressClassAnnotationKey)},
+	}
 }
 
 // Validate inspects and validates IngressSpec object.
@@ -47,8 +90,53 @@ func (spec *IngressSpec) Validate(ctx context.Context) *apis.FieldError {
 		all = all.Also(rule.Validate(ctx).ViaFieldIndex("rules", idx))
 	}
 	// TLS settings are optional.  However, all provided settings should be valid.
+	tlsHosts := sets.NewString()
 	for idx, tls := range spec.TLS {
 		all = all.Also(tls.Validate(ctx).ViaFieldIndex("tls", idx))
+		tlsHosts.Insert(tls.Hosts...)
+	}
+	// Rules served over HTTPS need a certificate for each of their hosts.
+	for idx, rule := range spec.Rules {
+		if rule.Protocol != ListenerProtocolHTTPS {
+			continue
+		}
+		for hidx, host := range rule.Hosts {
+			if !tlsHosts.Has(host) {
+				all = all.Also(apis.ErrGeneric("host is not covered by the TLS settings", apis.CurrentField).
+					ViaFieldIndex("hosts", hidx).ViaFieldIndex("rules", idx))
+			}
+		}
+	}
+	if spec.AccessLog != nil {
+		all = all.Also(spec.AccessLog.Validate(ctx).ViaField("accessLog"))
+	}
+	if spec.DefaultBackend != nil {
+		all = all.Also(spec.DefaultBackend.Validate(ctx).ViaField("defaultBackend"))
+	}
+	return all
+}
+
+// Validate inspects and validates AccessLog object.
+func (a *AccessLog) Validate(context.Context) *apis.FieldError {
+	var all *apis.FieldError
+	if a.Format != "" {
+		if _, err := network.ParseAccessLogTemplate(a.Format); err != nil {
+			all = all.Also(&apis.FieldError{
+				Message: "invalid access log format",
+				Paths:   []string{"format"},
+				Details: err.Error(),
+			})
+		}
+	}
+	for idx, header := range a.RequestHeaders {
+		if !httpguts.ValidHeaderFieldName(header) {
+			all = all.Also(apis.ErrInvalidArrayValue(header, "requestHeaders", idx))
+		}
+	}
+	for idx, header := range a.ResponseHeaders {
+		if !httpguts.ValidHeaderFieldName(header) {
+			all = all.Also(apis.ErrInvalidArrayValue(header, "responseHeaders", idx))
+		}
 	}
 	return all
 }
@@ -65,6 +153,48 @@ func (r *IngressRule) Validate(ctx context.Context) *apis.FieldError {
 	} else {
 		all = all.Also(r.HTTP.Validate(ctx).ViaField("http"))
 	}
+	all = all.Also(validateSourceRanges(r.AllowedSourceRanges, "allowedSourceRanges"))
+	all = all.Also(validateSourceRanges(r.DeniedSourceRanges, "deniedSourceRanges"))
+	if r.DefaultBackend != nil {
+		all = all.Also(r.DefaultBackend.Validate(ctx).ViaField("defaultBackend"))
+	}
+	if r.Port < 0 || r.Port > 65535 {
+		all = all.Also(apis.ErrOutOfBoundsValue(r.Port, 1, 65535, "port"))
+	}
+	switch r.Protocol {
+	case "":
+	case ListenerProtocolHTTP, ListenerProtocolHTTPS:
+		if r.Port == 0 {
+			all = all.Also(&apis.FieldError{
+				Message: "protocol may only be set along with port",
+				Paths:   []string{"protocol"},
+			})
+		}
+	default:
+		all = all.Also(apis.ErrInvalidValue(r.Protocol, "protocol"))
+	}
+	seen := sets.NewInt()
+	for idx, er := range r.ErrorResponses {
+		all = all.Also(er.Validate(ctx).ViaFieldIndex("errorResponses", idx))
+		for sidx, code := range er.StatusCodes {
+			if seen.Has(code) {
+				all = all.Also(apis.ErrGeneric("duplicate status code", apis.CurrentField).
+					ViaFieldIndex("statusCodes", sidx).ViaFieldIndex("errorResponses", idx))
+			}
+			seen.Insert(code)
+		}
+	}
+	return all
+}
+
+// validateSourceRanges checks that each of the given ranges is a valid CIDR.
+func validateSourceRanges(ranges []string, field string) *apis.FieldError {
+	var all *apis.FieldError
+	for idx, cidr := range ranges {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			all = all.Also(apis.ErrInvalidArrayValue(cidr, field, idx))
+		}
+	}
 	return all
 }
 
@@ -107,7 +237,188 @@ func (h HTTPIngressPath) Validate(ctx context.Context) *apis.FieldError {
 			})
 		}
 	}
+	if h.JWT != nil {
+		all = all.Also(h.JWT.Validate(ctx).ViaField("jwt"))
+	}
+	if h.ExtAuthz != nil {
+		all = all.Also(h.ExtAuthz.Validate(ctx).ViaField("extAuthz"))
+	}
+	if h.Compression != nil {
+		all = all.Also(h.Compression.Validate(ctx).ViaField("compression"))
+	}
+
+	return all
+}
+
+// Validate inspects and validates JWTAuthentication object.
+func (j *JWTAuthentication) Validate(ctx context.Context) *apis.FieldError {
+	var all *apis.FieldError
+	if len(j.Issuers) == 0 {
+		all = all.Also(apis.ErrMissingField("issuers"))
+	}
+	for idx, iss := range j.Issuers {
+		if iss == "" {
+			all = all.Also(apis.ErrInvalidArrayValue(iss, "issuers", idx))
+		}
+	}
+	for idx, aud := range j.Audiences {
+		if aud == "" {
+			all = all.Also(apis.ErrInvalidArrayValue(aud, "audiences", idx))
+		}
+	}
+	all = all.Also(j.JWKS.Validate(ctx).ViaField("jwks"))
+
+	if j.FromHeader != "" && j.FromCookie != "" {
+		all = all.Also(apis.ErrMultipleOneOf("fromHeader", "fromCookie"))
+	}
+	if j.FromHeader != "" && !httpguts.ValidHeaderFieldName(j.FromHeader) {
+		all = all.Also(apis.ErrInvalidValue(j.FromHeader, "fromHeader"))
+	}
+	// Cookie names share the token grammar of header names (RFC 6265).
+	if j.FromCookie != "" && !httpguts.ValidHeaderFieldName(j.FromCookie) {
+		all = all.Also(apis.ErrInvalidValue(j.FromCookie, "fromCookie"))
+	}
+	for claim, header := range j.ForwardClaims {
+		if claim == "" {
+			all = all.Also(apis.ErrInvalidKeyName(claim, "forwardClaims"))
+		}
+		if !httpguts.ValidHeaderFieldName(header) {
+			all = all.Also(apis.ErrInvalidValue(header, apis.CurrentField).ViaFieldKey("forwardClaims", claim))
+		}
+	}
+	return all
+}
+
+// Validate inspects and validates ExtAuthz object.
+func (e *ExtAuthz) Validate(ctx context.Context) *apis.FieldError {
+	all := e.Service.Validate(ctx).ViaField("service")
+	for idx, header := range e.RequestHeaders {
+		if !httpguts.ValidHeaderFieldName(header) {
+			all = all.Also(apis.ErrInvalidArrayValue(header, "requestHeaders", idx))
+		}
+	}
+	for idx, header := range e.UpstreamHeaders {
+		if !httpguts.ValidHeaderFieldName(header) {
+			all = all.Also(apis.ErrInvalidArrayValue(header, "upstreamHeaders", idx))
+		}
+	}
+	if e.Timeout != nil && e.Timeout.Duration <= 0 {
+		all = all.Also(apis.ErrInvalidValue(e.Timeout.Duration, "timeout"))
+	}
+	switch e.FailureMode {
+	case "", ExtAuthzFailureModeDeny, ExtAuthzFailureModeAllow:
+	default:
+		all = all.Also(apis.ErrInvalidValue(e.FailureMode, "failureMode"))
+	}
+	return all
+}
+
+// Validate inspects and validates Compression object.
+func (c *Compression) Validate(context.Context) *apis.FieldError {
+	var all *apis.FieldError
+	seen := make(map[CompressionAlgorithm]struct{}, len(c.Algorithms))
+	for idx, alg := range c.Algorithms {
+		switch alg {
+		case CompressionAlgorithmGzip, CompressionAlgorithmBrotli:
+			if _, ok := seen[alg]; ok {
+				all = all.Also(apis.ErrGeneric("duplicate compression algorithm", apis.CurrentField).
+					ViaFieldIndex("algorithms", idx))
+			}
+			seen[alg] = struct{}{}
+		default:
+			all = all.Also(apis.ErrInvalidArrayValue(alg, "algorithms", idx))
+		}
+	}
+	if c.MinimumSize < 0 {
+		all = all.Also(apis.ErrInvalidValue(c.MinimumSize, "minimumSize"))
+	}
+	for idx, ct := range c.ContentTypes {
+		if _, _, err := mime.ParseMediaType(ct); err != nil {
+			all = all.Also(apis.ErrInvalidArrayValue(ct, "contentTypes", idx))
+		}
+	}
+	return all
+}
+
+// Validate inspects and validates JWKSSource object.
+func (s *JWKSSource) Validate(ctx context.Context) *apis.FieldError {
+	switch {
+	case s.Inline == "" && s.SecretRef == nil:
+		return apis.ErrMissingOneOf("inline", "secretRef")
+	case s.Inline != "" && s.SecretRef != nil:
+		return apis.ErrMultipleOneOf("inline", "secretRef")
+	case s.SecretRef != nil:
+		return s.SecretRef.Validate(ctx).ViaField("secretRef")
+	}
+	var set struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.Unmarshal([]byte(s.Inline), &set); err != nil {
+		return &apis.FieldError{
+			Message: "invalid JSON Web Key Set",
+			Paths:   []string{"inline"},
+			Details: err.Error(),
+		}
+	}
+	if len(set.Keys) == 0 {
+		return &apis.FieldError{
+			Message: "JSON Web Key Set must contain at least one key",
+			Paths:   []string{"inline"},
+		}
+	}
+	return nil
+}
+
+// Validate inspects and validates ErrorResponse object.
+func (e *ErrorResponse) Validate(ctx context.Context) *apis.FieldError {
+	var all *apis.FieldError
+	if len(e.StatusCodes) == 0 {
+		all = all.Also(apis.ErrMissingField("statusCodes"))
+	}
+	for idx, code := range e.StatusCodes {
+		if code < 400 || code > 599 {
+			all = all.Also(apis.ErrInvalidArrayValue(code, "statusCodes", idx))
+		}
+	}
+	all = all.Also(e.Body.Validate(ctx).ViaField("body"))
+	if e.ContentType != "" {
+		if _, _, err := mime.ParseMediaType(e.ContentType); err != nil {
+			all = all.Also(apis.ErrInvalidValue(e.ContentType, "contentType"))
+		}
+	}
+	if e.StatusCode != 0 && (e.StatusCode < 100 || e.StatusCode > 599) {
+		all = all.Also(apis.ErrInvalidValue(e.StatusCode, "statusCode"))
+	}
+	return all
+}
+
+// Validate inspects and validates ConfigMapKeyReference object.
+func (r *ConfigMapKeyReference) Validate(context.Context) *apis.FieldError {
+	var all *apis.FieldError
+	if r.ConfigMapName == "" {
+		all = all.Also(apis.ErrMissingField("configMapName"))
+	}
+	if r.ConfigMapNamespace == "" {
+		all = all.Also(apis.ErrMissingField("configMapNamespace"))
+	}
+	if r.Key == "" {
+		all = all.Also(apis.ErrMissingField("key"))
+	}
+	return all
+}
 
+// Validate inspects and validates SecretKeyReference object.
+func (r *SecretKeyReference) Validate(context.Context) *apis.FieldError {
+	var all *apis.FieldError
+	if r.SecretName == "" {
+		all = all.Also(apis.ErrMissingField("secretName"))
+	}
+	if r.SecretNamespace == "" {
+		all = all.Also(apis.ErrMissingField("secretNamespace"))
+	}
+	if r.Key == "" {
+		all = all.Also(apis.ErrMissingField("key"))
+	}
 	return all
 }
 
@@ -122,9 +433,79 @@ func (s IngressBackendSplit) Validate(ctx context.Context) *apis.FieldError {
 	if s.Percent < 0 || s.Percent > 100 {
 		all = all.Also(apis.ErrInvalidValue(s.Percent, "percent"))
 	}
+	if s.Locality != nil {
+		all = all.Also(s.Locality.Validate(ctx).ViaField("locality"))
+	}
 	return all.Also(s.IngressBackend.Validate(ctx))
 }
 
+// Validate inspects and validates LocalityPreferences object.
+func (l *LocalityPreferences) Validate(ctx context.Context) *apis.FieldError {
+	var all *apis.FieldError
+	if len(l.Distribution) > 0 {
+		if l.PreferSameZone {
+			all = all.Also(apis.ErrMultipleOneOf("distribution", "preferSameZone"))
+		}
+		if len(l.FailoverZones) > 0 {
+			all = all.Also(apis.ErrMultipleOneOf("distribution", "failoverZones"))
+		}
+	}
+	if len(l.FailoverZones) > 0 && !l.PreferSameZone {
+		all = all.Also(&apis.FieldError{
+			Message: "failoverZones requires preferSameZone",
+			Paths:   []string{"failoverZones"},
+		})
+	}
+	zones := sets.NewString()
+	for idx, zone := range l.FailoverZones {
+		switch {
+		case zone == "":
+			all = all.Also(apis.ErrInvalidArrayValue(zone, "failoverZones", idx))
+		case zones.Has(zone):
+			all = all.Also(apis.ErrGeneric("duplicate zone", apis.CurrentField).
+				ViaFieldIndex("failoverZones", idx))
+		}
+		zones.Insert(zone)
+	}
+	from := sets.NewString()
+	for idx, d := range l.Distribution {
+		if from.Has(d.From) {
+			all = all.Also(apis.ErrGeneric("duplicate zone", "from").ViaFieldIndex("distribution", idx))
+		}
+		from.Insert(d.From)
+		all = all.Also(d.Validate(ctx).ViaFieldIndex("distribution", idx))
+	}
+	return all
+}
+
+// Validate inspects and validates LocalityDistribution object.
+func (d *LocalityDistribution) Validate(context.Context) *apis.FieldError {
+	var all *apis.FieldError
+	if d.From == "" {
+		all = all.Also(apis.ErrMissingField("from"))
+	}
+	if len(d.To) == 0 {
+		return all.Also(apis.ErrMissingField("to"))
+	}
+	totalPct := 0
+	for zone, pct := range d.To {
+		if zone == "" {
+			all = all.Also(apis.ErrInvalidKeyName(zone, "to"))
+		}
+		if pct < 0 || pct > 100 {
+			all = all.Also(apis.ErrInvalidValue(pct, apis.CurrentField).ViaFieldKey("to", zone))
+		}
+		totalPct += pct
+	}
+	if totalPct != 100 {
+		all = all.Also(&apis.FieldError{
+			Message: "zone percentage must total to 100, but was " + strconv.Itoa(totalPct),
+			Paths:   []string{"to"},
+		})
+	}
+	return all
+}
+
 // Validate inspects the fields of the type IngressBackend
 // to determine if they are valid.
 func (b IngressBackend) Validate(ctx context.Context) *apis.FieldError {
@@ -147,6 +528,9 @@ func (b IngressBackend) Validate(ctx context.Context) *apis.FieldError {
 	if equality.Semantic.DeepEqual(b.ServicePort, intstr.IntOrString{}) {
 		all = all.Also(apis.ErrMissingField("servicePort"))
 	}
+	if b.Protocol != "" {
+		all = all.Also(b.Protocol.Validate(ctx).ViaField("protocol"))
+	}
 	return all
 }
 
@@ -19,14 +19,23 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/networking/pkg/apis/networking"
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
 )
 
 func TestIngressSpecValidation(t *testing.T) {
+	validBody := ConfigMapKeyReference{
+		ConfigMapName:      "error-pages",
+		ConfigMapNamespace: "default",
+		Key:                "503.html",
+	}
 	tests := []struct {
 		name string
 		is   *IngressSpec
@@ -247,6 +256,271 @@ func TestIngressSpecValidation(t *testing.T) {
 			}},
 		},
 		want: apis.ErrMissingField("rules[0].http.paths[0].splits[0].servicePort"),
+	}, {
+		name: "valid-backend-protocol",
+		is: &IngressSpec{
+			Rules: []IngressRule{{
+				Hosts: []string{"example.com"},
+				HTTP: &HTTPIngressRuleValue{
+					Paths: []HTTPIngressPath{{
+						Splits: []IngressBackendSplit{{
+							IngressBackend: IngressBackend{
+								ServiceName:      "service-name",
+								ServiceNamespace: "default",
+								ServicePort:      intstr.FromString("grpc"),
+								Protocol:         networking.ProtocolGRPC,
+							},
+						}},
+					}},
+				},
+			}},
+		},
+		want: nil,
+	}, {
+		name: "invalid-backend-protocol",
+		is: &IngressSpec{
+			Rules: []IngressRule{{
+				Hosts: []string{"example.com"},
+				HTTP: &HTTPIngressRuleValue{
+					Paths: []HTTPIngressPath{{
+						Splits: []IngressBackendSplit{{
+							IngressBackend: IngressBackend{
+								ServiceName:      "service-name",
+								ServiceNamespace: "default",
+								ServicePort:      intstr.FromInt(8080),
+								Protocol:         networking.ProtocolType("spdy"),
+							},
+						}},
+					}},
+				},
+			}},
+		},
+		want: apis.ErrInvalidValue("spdy", "rules[0].http.paths[0].splits[0].protocol"),
+	}, {
+		name: "valid-source-ranges",
+		is: &IngressSpec{
+			Rules: []IngressRule{{
+				Hosts:               []string{"example.com"},
+				AllowedSourceRanges: []string{"10.0.0.0/8", "2001:db8::/32"},
+				DeniedSourceRanges:  []string{"10.1.2.3/32"},
+				HTTP: &HTTPIngressRuleValue{
+					Paths: []HTTPIngressPath{{
+						Splits: []IngressBackendSplit{{
+							IngressBackend: IngressBackend{
+								ServiceName:      "service-name",
+								ServiceNamespace: "default",
+								ServicePort:      intstr.FromInt(8080),
+							},
+						}},
+					}},
+				},
+			}},
+		},
+		want: nil,
+	}, {
+		name: "valid-default-backends",
+		is: &IngressSpec{
+			Rules: []IngressRule{{
+				Hosts: []string{"example.com"},
+				HTTP: &HTTPIngressRuleValue{
+					Paths: []HTTPIngressPath{{
+						Path: "/foo",
+						Splits: []IngressBackendSplit{{
+							IngressBackend: IngressBackend{
+								ServiceName:      "service-name",
+								ServiceNamespace: "default",
+								ServicePort:      intstr.FromInt(8080),
+							},
+						}},
+					}},
+				},
+				DefaultBackend: &IngressBackend{
+					ServiceName:      "rule-fallback",
+					ServiceNamespace: "default",
+					ServicePort:      intstr.FromInt(8080),
+				},
+			}},
+			DefaultBackend: &IngressBackend{
+				ServiceName:      "fallback",
+				ServiceNamespace: "default",
+				ServicePort:      intstr.FromInt(8080),
+			},
+		},
+		want: nil,
+	}, {
+		name: "invalid-default-backends",
+		is: &IngressSpec{
+			Rules: []IngressRule{{
+				Hosts: []string{"example.com"},
+				HTTP: &HTTPIngressRuleValue{
+					Paths: []HTTPIngressPath{{
+						Splits: []IngressBackendSplit{{
+							IngressBackend: IngressBackend{
+								ServiceName:      "service-name",
+								ServiceNamespace: "default",
+								ServicePort:      intstr.FromInt(8080),
+							},
+						}},
+					}},
+				},
+				DefaultBackend: &IngressBackend{
+					ServiceNamespace: "default",
+					ServicePort:      intstr.FromInt(8080),
+				},
+			}},
+			DefaultBackend: &IngressBackend{
+				ServiceName:      "fallback",
+				ServiceNamespace: "other",
+				ServicePort:      intstr.FromInt(8080),
+			},
+		},
+		want: apis.ErrMissingField("rules[0].defaultBackend.serviceName").Also(&apis.FieldError{
+			Message: "service namespace must match ingress namespace",
+			Paths:   []string{"defaultBackend.serviceNamespace"},
+		}),
+	}, {
+		name: "valid-listener",
+		is: &IngressSpec{
+			Rules: []IngressRule{{
+				Hosts:    []string{"example.com"},
+				Port:     8443,
+				Protocol: ListenerProtocolHTTPS,
+				HTTP: &HTTPIngressRuleValue{
+					Paths: []HTTPIngressPath{{
+						Splits: []IngressBackendSplit{{
+							IngressBackend: IngressBackend{
+								ServiceName:      "service-name",
+								ServiceNamespace: "default",
+								ServicePort:      intstr.FromInt(8080),
+							},
+						}},
+					}},
+				},
+			}},
+			TLS: []IngressTLS{{
+				Hosts:           []string{"example.com"},
+				SecretName:      "secret",
+				SecretNamespace: "default",
+			}},
+		},
+		want: nil,
+	}, {
+		name: "invalid-listener",
+		is: &IngressSpec{
+			Rules: []IngressRule{{
+				Hosts:    []string{"example.com"},
+				Port:     70000,
+				Protocol: "UDP",
+				HTTP: &HTTPIngressRuleValue{
+					Paths: []HTTPIngressPath{{
+						Splits: []IngressBackendSplit{{
+							IngressBackend: IngressBackend{
+								ServiceName:      "service-name",
+								ServiceNamespace: "default",
+								ServicePort:      intstr.FromInt(8080),
+							},
+						}},
+					}},
+				},
+			}, {
+				Hosts:    []string{"secure.example.com", "insecure.example.com"},
+				Protocol: ListenerProtocolHTTPS,
+				HTTP: &HTTPIngressRuleValue{
+					Paths: []HTTPIngressPath{{
+						Splits: []IngressBackendSplit{{
+							IngressBackend: IngressBackend{
+								ServiceName:      "service-name",
+								ServiceNamespace: "default",
+								ServicePort:      intstr.FromInt(8080),
+							},
+						}},
+					}},
+				},
+			}},
+			TLS: []IngressTLS{{
+				Hosts:           []string{"secure.example.com"},
+				SecretName:      "secret",
+				SecretNamespace: "default",
+			}},
+		},
+		want: apis.ErrOutOfBoundsValue(70000, 1, 65535, "rules[0].port").Also(
+			apis.ErrInvalidValue("UDP", "rules[0].protocol"),
+			&apis.FieldError{
+				Message: "protocol may only be set along with port",
+				Paths:   []string{"rules[1].protocol"},
+			},
+			apis.ErrGeneric("host is not covered by the TLS settings", "rules[1].hosts[1]"),
+		),
+	}, {
+		name: "invalid-source-ranges",
+		is: &IngressSpec{
+			Rules: []IngressRule{{
+				Hosts:               []string{"example.com"},
+				AllowedSourceRanges: []string{"10.0.0.0/8", "10.0.0.1"},
+				DeniedSourceRanges:  []string{"not-a-cidr"},
+				HTTP: &HTTPIngressRuleValue{
+					Paths: []HTTPIngressPath{{
+						Splits: []IngressBackendSplit{{
+							IngressBackend: IngressBackend{
+								ServiceName:      "service-name",
+								ServiceNamespace: "default",
+								ServicePort:      intstr.FromInt(8080),
+							},
+						}},
+					}},
+				},
+			}},
+		},
+		want: apis.ErrInvalidArrayValue("10.0.0.1", "rules[0].allowedSourceRanges", 1).Also(
+			apis.ErrInvalidArrayValue("not-a-cidr", "rules[0].deniedSourceRanges", 0)),
+	}, {
+		name: "duplicate-error-response-status",
+		is: &IngressSpec{
+			Rules: []IngressRule{{
+				Hosts: []string{"example.com"},
+				HTTP: &HTTPIngressRuleValue{
+					Paths: []HTTPIngressPath{{
+						Splits: []IngressBackendSplit{{
+							IngressBackend: IngressBackend{
+								ServiceName:      "service-name",
+								ServiceNamespace: "default",
+								ServicePort:      intstr.FromInt(8080),
+							},
+						}},
+					}},
+				},
+				ErrorResponses: []ErrorResponse{{
+					StatusCodes: []int{502, 503},
+					Body:        validBody,
+				}, {
+					StatusCodes: []int{504, 503},
+					Body:        validBody,
+				}},
+			}},
+		},
+		want: apis.ErrGeneric("duplicate status code", "rules[0].errorResponses[1].statusCodes[1]"),
+	}, {
+		name: "jwt-missing-issuers",
+		is: &IngressSpec{
+			Rules: []IngressRule{{
+				Hosts: []string{"example.com"},
+				HTTP: &HTTPIngressRuleValue{
+					Paths: []HTTPIngressPath{{
+						Splits: []IngressBackendSplit{{
+							IngressBackend: IngressBackend{
+								ServiceName:      "service-name",
+								ServiceNamespace: "default",
+								ServicePort:      intstr.FromInt(8080),
+							},
+						}},
+						JWT: &JWTAuthentication{
+							JWKS: JWKSSource{Inline: `{"keys":[{"kty":"oct"}]}`},
+						},
+					}},
+				},
+			}},
+		},
+		want: apis.ErrMissingField("rules[0].http.paths[0].jwt.issuers"),
 	}, {
 		name: "split-percent-sum-not-100",
 		is: &IngressSpec{
@@ -347,6 +621,456 @@ func TestIngressSpecValidation(t *testing.T) {
 	}
 }
 
+func TestJWTAuthenticationValidation(t *testing.T) {
+	const jwks = `{"keys":[{"kty":"RSA","kid":"test","n":"AQAB","e":"AQAB"}]}`
+	tests := []struct {
+		name string
+		jwt  *JWTAuthentication
+		want *apis.FieldError
+	}{{
+		name: "valid inline",
+		jwt: &JWTAuthentication{
+			Issuers:   []string{"https://issuer.example.com"},
+			Audiences: []string{"my-service"},
+			JWKS:      JWKSSource{Inline: jwks},
+			ForwardClaims: map[string]string{
+				"sub": "X-User",
+			},
+		},
+	}, {
+		name: "valid secret ref",
+		jwt: &JWTAuthentication{
+			Issuers: []string{"https://issuer.example.com"},
+			JWKS: JWKSSource{SecretRef: &SecretKeyReference{
+				SecretName:      "jwks",
+				SecretNamespace: "default",
+				Key:             "jwks.json",
+			}},
+			FromCookie: "session",
+		},
+	}, {
+		name: "missing issuers",
+		jwt: &JWTAuthentication{
+			JWKS: JWKSSource{Inline: jwks},
+		},
+		want: apis.ErrMissingField("issuers"),
+	}, {
+		name: "empty audience",
+		jwt: &JWTAuthentication{
+			Issuers:   []string{"https://issuer.example.com"},
+			Audiences: []string{""},
+			JWKS:      JWKSSource{Inline: jwks},
+		},
+		want: apis.ErrInvalidArrayValue("", "audiences", 0),
+	}, {
+		name: "missing jwks",
+		jwt: &JWTAuthentication{
+			Issuers: []string{"https://issuer.example.com"},
+		},
+		want: apis.ErrMissingOneOf("jwks.inline", "jwks.secretRef"),
+	}, {
+		name: "both jwks sources",
+		jwt: &JWTAuthentication{
+			Issuers: []string{"https://issuer.example.com"},
+			JWKS: JWKSSource{
+				Inline: jwks,
+				SecretRef: &SecretKeyReference{
+					SecretName:      "jwks",
+					SecretNamespace: "default",
+					Key:             "jwks.json",
+				},
+			},
+		},
+		want: apis.ErrMultipleOneOf("jwks.inline", "jwks.secretRef"),
+	}, {
+		name: "malformed inline jwks",
+		jwt: &JWTAuthentication{
+			Issuers: []string{"https://issuer.example.com"},
+			JWKS:    JWKSSource{Inline: "{"},
+		},
+		want: &apis.FieldError{
+			Message: "invalid JSON Web Key Set",
+			Paths:   []string{"jwks.inline"},
+			Details: "unexpected end of JSON input",
+		},
+	}, {
+		name: "inline jwks without keys",
+		jwt: &JWTAuthentication{
+			Issuers: []string{"https://issuer.example.com"},
+			JWKS:    JWKSSource{Inline: `{"keys":[]}`},
+		},
+		want: &apis.FieldError{
+			Message: "JSON Web Key Set must contain at least one key",
+			Paths:   []string{"jwks.inline"},
+		},
+	}, {
+		name: "incomplete secret ref",
+		jwt: &JWTAuthentication{
+			Issuers: []string{"https://issuer.example.com"},
+			JWKS: JWKSSource{SecretRef: &SecretKeyReference{
+				SecretName: "jwks",
+			}},
+		},
+		want: apis.ErrMissingField("jwks.secretRef.secretNamespace", "jwks.secretRef.key"),
+	}, {
+		name: "header and cookie",
+		jwt: &JWTAuthentication{
+			Issuers:    []string{"https://issuer.example.com"},
+			JWKS:       JWKSSource{Inline: jwks},
+			FromHeader: "X-Token",
+			FromCookie: "token",
+		},
+		want: apis.ErrMultipleOneOf("fromHeader", "fromCookie"),
+	}, {
+		name: "invalid header",
+		jwt: &JWTAuthentication{
+			Issuers:    []string{"https://issuer.example.com"},
+			JWKS:       JWKSSource{Inline: jwks},
+			FromHeader: "X Token",
+		},
+		want: apis.ErrInvalidValue("X Token", "fromHeader"),
+	}, {
+		name: "invalid forwarded claim header",
+		jwt: &JWTAuthentication{
+			Issuers: []string{"https://issuer.example.com"},
+			JWKS:    JWKSSource{Inline: jwks},
+			ForwardClaims: map[string]string{
+				"sub": "X:User",
+			},
+		},
+		want: apis.ErrInvalidValue("X:User", "forwardClaims[sub]"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.jwt.Validate(context.Background())
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestExtAuthzValidation(t *testing.T) {
+	authz := IngressBackend{
+		ServiceName:      "authz",
+		ServiceNamespace: "default",
+		ServicePort:      intstr.FromInt(8080),
+	}
+	tests := []struct {
+		name string
+		ea   *ExtAuthz
+		want *apis.FieldError
+	}{{
+		name: "valid",
+		ea: &ExtAuthz{
+			Service:         authz,
+			RequestHeaders:  []string{"Authorization", "Cookie"},
+			UpstreamHeaders: []string{"X-User"},
+			Timeout:         &metav1.Duration{Duration: time.Second},
+			FailureMode:     ExtAuthzFailureModeAllow,
+		},
+	}, {
+		name: "missing service",
+		ea:   &ExtAuthz{},
+		want: apis.ErrMissingField("service"),
+	}, {
+		name: "service in other namespace",
+		ea: &ExtAuthz{
+			Service: IngressBackend{
+				ServiceName:      "authz",
+				ServiceNamespace: "elsewhere",
+				ServicePort:      intstr.FromInt(8080),
+			},
+		},
+		want: &apis.FieldError{
+			Message: "service namespace must match ingress namespace",
+			Paths:   []string{"service.serviceNamespace"},
+		},
+	}, {
+		name: "invalid headers",
+		ea: &ExtAuthz{
+			Service:         authz,
+			RequestHeaders:  []string{"Authorization", "Bad Header"},
+			UpstreamHeaders: []string{""},
+		},
+		want: apis.ErrInvalidArrayValue("Bad Header", "requestHeaders", 1).Also(
+			apis.ErrInvalidArrayValue("", "upstreamHeaders", 0)),
+	}, {
+		name: "non-positive timeout",
+		ea: &ExtAuthz{
+			Service: authz,
+			Timeout: &metav1.Duration{},
+		},
+		want: apis.ErrInvalidValue(time.Duration(0), "timeout"),
+	}, {
+		name: "invalid failure mode",
+		ea: &ExtAuthz{
+			Service:     authz,
+			FailureMode: "Maybe",
+		},
+		want: apis.ErrInvalidValue("Maybe", "failureMode"),
+	}}
+
+	ctx := apis.WithinParent(context.Background(), metav1.ObjectMeta{Namespace: "default", Name: "test-ingress"})
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.ea.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestCompressionValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *Compression
+		want *apis.FieldError
+	}{{
+		name: "empty",
+		c:    &Compression{},
+	}, {
+		name: "valid",
+		c: &Compression{
+			Algorithms:   []CompressionAlgorithm{CompressionAlgorithmBrotli, CompressionAlgorithmGzip},
+			MinimumSize:  1024,
+			ContentTypes: []string{"text/html", "application/json; charset=utf-8"},
+		},
+	}, {
+		name: "unknown algorithm",
+		c: &Compression{
+			Algorithms: []CompressionAlgorithm{"zstd"},
+		},
+		want: apis.ErrInvalidArrayValue("zstd", "algorithms", 0),
+	}, {
+		name: "duplicate algorithm",
+		c: &Compression{
+			Algorithms: []CompressionAlgorithm{CompressionAlgorithmGzip, CompressionAlgorithmGzip},
+		},
+		want: apis.ErrGeneric("duplicate compression algorithm", "algorithms[1]"),
+	}, {
+		name: "negative minimum size",
+		c: &Compression{
+			MinimumSize: -1,
+		},
+		want: apis.ErrInvalidValue(-1, "minimumSize"),
+	}, {
+		name: "invalid content type",
+		c: &Compression{
+			ContentTypes: []string{"text/plain", "not a/media type"},
+		},
+		want: apis.ErrInvalidArrayValue("not a/media type", "contentTypes", 1),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.c.Validate(context.Background())
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestErrorResponseValidation(t *testing.T) {
+	validBody := ConfigMapKeyReference{
+		ConfigMapName:      "error-pages",
+		ConfigMapNamespace: "default",
+		Key:                "503.html",
+	}
+	tests := []struct {
+		name string
+		er   *ErrorResponse
+		want *apis.FieldError
+	}{{
+		name: "valid",
+		er: &ErrorResponse{
+			StatusCodes: []int{502, 503, 504},
+			Body:        validBody,
+			ContentType: "text/html; charset=utf-8",
+			StatusCode:  503,
+		},
+	}, {
+		name: "missing status codes and body",
+		er:   &ErrorResponse{},
+		want: apis.ErrMissingField("statusCodes", "body.configMapName",
+			"body.configMapNamespace", "body.key"),
+	}, {
+		name: "non-error status codes",
+		er: &ErrorResponse{
+			StatusCodes: []int{200, 404, 600},
+			Body:        validBody,
+		},
+		want: apis.ErrInvalidArrayValue(200, "statusCodes", 0).Also(
+			apis.ErrInvalidArrayValue(600, "statusCodes", 2)),
+	}, {
+		name: "invalid content type",
+		er: &ErrorResponse{
+			StatusCodes: []int{503},
+			Body:        validBody,
+			ContentType: "text/",
+		},
+		want: apis.ErrInvalidValue("text/", "contentType"),
+	}, {
+		name: "invalid replacement status code",
+		er: &ErrorResponse{
+			StatusCodes: []int{503},
+			Body:        validBody,
+			StatusCode:  1000,
+		},
+		want: apis.ErrInvalidValue(1000, "statusCode"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.er.Validate(context.Background())
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestLocalityPreferencesValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		lp   *LocalityPreferences
+		want *apis.FieldError
+	}{{
+		name: "empty",
+		lp:   &LocalityPreferences{},
+	}, {
+		name: "prefer same zone with failover",
+		lp: &LocalityPreferences{
+			PreferSameZone: true,
+			FailoverZones:  []string{"us-east1-b", "us-east1-c"},
+		},
+	}, {
+		name: "distribution",
+		lp: &LocalityPreferences{
+			Distribution: []LocalityDistribution{{
+				From: "us-east1-b",
+				To:   map[string]int{"us-east1-b": 80, "us-east1-c": 20},
+			}, {
+				From: "us-east1-c",
+				To:   map[string]int{"us-east1-c": 100},
+			}},
+		},
+	}, {
+		name: "distribution with other preferences",
+		lp: &LocalityPreferences{
+			PreferSameZone: true,
+			FailoverZones:  []string{"us-east1-c"},
+			Distribution: []LocalityDistribution{{
+				From: "us-east1-b",
+				To:   map[string]int{"us-east1-b": 100},
+			}},
+		},
+		want: apis.ErrMultipleOneOf("distribution", "preferSameZone").Also(
+			apis.ErrMultipleOneOf("distribution", "failoverZones")),
+	}, {
+		name: "failover without prefer same zone",
+		lp: &LocalityPreferences{
+			FailoverZones: []string{"us-east1-c"},
+		},
+		want: &apis.FieldError{
+			Message: "failoverZones requires preferSameZone",
+			Paths:   []string{"failoverZones"},
+		},
+	}, {
+		name: "invalid failover zones",
+		lp: &LocalityPreferences{
+			PreferSameZone: true,
+			FailoverZones:  []string{"us-east1-c", "", "us-east1-c"},
+		},
+		want: apis.ErrInvalidArrayValue("", "failoverZones", 1).Also(
+			apis.ErrGeneric("duplicate zone", "failoverZones[2]")),
+	}, {
+		name: "invalid distribution",
+		lp: &LocalityPreferences{
+			Distribution: []LocalityDistribution{{
+				To: map[string]int{"us-east1-b": 120, "": 10},
+			}, {
+				From: "us-east1-c",
+			}, {
+				From: "us-east1-c",
+				To:   map[string]int{"us-east1-c": 50},
+			}},
+		},
+		want: apis.ErrMissingField("distribution[0].from").Also(
+			apis.ErrInvalidKeyName("", "distribution[0].to"),
+			apis.ErrInvalidValue(120, "distribution[0].to[us-east1-b]"),
+			&apis.FieldError{
+				Message: "zone percentage must total to 100, but was 130",
+				Paths:   []string{"distribution[0].to"},
+			},
+			apis.ErrMissingField("distribution[1].to"),
+			apis.ErrGeneric("duplicate zone", "distribution[2].from"),
+			&apis.FieldError{
+				Message: "zone percentage must total to 100, but was 50",
+				Paths:   []string{"distribution[2].to"},
+			},
+		),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.lp.Validate(context.Background())
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestAccessLogValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		al   *AccessLog
+		want *apis.FieldError
+	}{{
+		name: "empty",
+		al:   &AccessLog{},
+	}, {
+		name: "valid",
+		al: &AccessLog{
+			Enabled:         ptr.Bool(true),
+			Format:          `{{.Host}} {{.Status}} {{index .ResponseHeaders "Content-Type"}}`,
+			RequestHeaders:  []string{"User-Agent"},
+			ResponseHeaders: []string{"Content-Type"},
+		},
+	}, {
+		name: "unknown variable",
+		al: &AccessLog{
+			Format: "{{.Hostname}}",
+		},
+		want: &apis.FieldError{
+			Message: "invalid access log format",
+			Paths:   []string{"format"},
+			Details: `template: access-log-template:1:2: executing "access-log-template" at <.Hostname>: can't evaluate field Hostname in type pkg.AccessLogTemplateValues`,
+		},
+	}, {
+		name: "invalid headers",
+		al: &AccessLog{
+			RequestHeaders:  []string{"User Agent"},
+			ResponseHeaders: []string{"Content-Type", ""},
+		},
+		want: apis.ErrInvalidArrayValue("User Agent", "requestHeaders", 0).Also(
+			apis.ErrInvalidArrayValue("", "responseHeaders", 1)),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.al.Validate(context.Background())
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
 func TestIngressValidation(t *testing.T) {
 	tests := []struct {
 		name string
@@ -424,3 +1148,85 @@ func TestIngressValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestIngressClassAnnotationValidation(t *testing.T) {
+	installed := sets.NewString("installed.ingress.networking.knative.dev")
+	ingress := func(class string) *Ingress {
+		ing := &Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-ingress",
+			},
+			Spec: IngressSpec{
+				Rules: []IngressRule{{
+					Hosts: []string{"example.com"},
+					HTTP: &HTTPIngressRuleValue{
+						Paths: []HTTPIngressPath{{
+							Splits: []IngressBackendSplit{{
+								IngressBackend: IngressBackend{
+									ServiceName:      "revision-000",
+									ServiceNamespace: "default",
+									ServicePort:      intstr.FromInt(8080),
+								},
+							}},
+						}},
+					},
+				}},
+			},
+		}
+		if class != "" {
+			ing.Annotations = map[string]string{networking.IngressClassAnnotationKey: class}
+		}
+		return ing
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		ci   *Ingress
+		want *apis.FieldError
+	}{{
+		name: "no installed classes",
+		ctx:  context.Background(),
+		ci:   ingress("unknown.ingress.networking.knative.dev"),
+	}, {
+		name: "no annotation",
+		ctx:  WithInstalledIngressClasses(context.Background(), installed),
+		ci:   ingress(""),
+	}, {
+		name: "installed class",
+		ctx:  WithInstalledIngressClasses(context.Background(), installed),
+		ci:   ingress("installed.ingress.networking.knative.dev"),
+	}, {
+		name: "unknown class",
+		ctx:  WithInstalledIngressClasses(context.Background(), installed),
+		ci:   ingress("unknown.ingress.networking.knative.dev"),
+		want: &apis.FieldError{
+			Message: `ingress class "unknown.ingress.networking.knative.dev" is not installed`,
+			Paths:   []string{"metadata.annotations[networking.knative.dev/ingress.class]"},
+		},
+	}, {
+		name: "update keeping unknown class",
+		ctx: apis.WithinUpdate(WithInstalledIngressClasses(context.Background(), installed),
+			ingress("unknown.ingress.networking.knative.dev")),
+		ci: ingress("unknown.ingress.networking.knative.dev"),
+	}, {
+		name: "update to unknown class",
+		ctx: apis.WithinUpdate(WithInstalledIngressClasses(context.Background(), installed),
+			ingress("installed.ingress.networking.knative.dev")),
+		ci: ingress("unknown.ingress.networking.knative.dev"),
+		want: &apis.FieldError{
+			Message: `ingress class "unknown.ingress.networking.knative.dev" is not installed`,
+			Paths:   []string{"metadata.annotations[networking.knative.dev/ingress.class]"},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.ci.Validate(test.ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"knative.dev/pkg/apis"
+)
+
+func TestIngressClassGetConditionSet(t *testing.T) {
+	c := IngressClass{}
+
+	if got, want := c.GetConditionSet().GetTopLevelConditionType(), apis.ConditionReady; got != want {
+		t.Errorf("GetConditionSet=%v, want=%v", got, want)
+	}
+}
+
+func TestIngressClassGetGroupVersionKind(t *testing.T) {
+	c := IngressClass{}
+	expected := SchemeGroupVersion.WithKind("IngressClass")
+	if !cmp.Equal(expected, c.GetGroupVersionKind()) {
+		t.Errorf("Unexpected diff (-want, +got) = %v", cmp.Diff(expected, c.GetGroupVersionKind()))
+	}
+}
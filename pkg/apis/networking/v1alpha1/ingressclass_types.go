@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IngressClass is a cluster-scoped resource that each Ingress implementation
+// publishes to advertise the ingress class it implements, and the optional
+// features of the Ingress API it supports.  The name of the IngressClass is
+// the value of the `networking.knative.dev/ingress.class` annotation that
+// selects the implementation, e.g. `istio.ingress.networking.knative.dev`.
+type IngressClass struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the IngressClass.
+	// More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	// +optional
+	Spec IngressClassSpec `json:"spec,omitempty"`
+
+	// Status is the current state of the IngressClass.
+	// More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	// +optional
+	Status IngressClassStatus `json:"status,omitempty"`
+}
+
+// Verify that IngressClass adheres to the appropriate interfaces.
+var (
+	// Check that IngressClass may be validated and defaulted.
+	_ apis.Validatable = (*IngressClass)(nil)
+	_ apis.Defaultable = (*IngressClass)(nil)
+
+	// Check that we can create OwnerReferences to an IngressClass.
+	_ kmeta.OwnerRefable = (*IngressClass)(nil)
+
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*IngressClass)(nil)
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IngressClassList is a collection of IngressClass objects.
+type IngressClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object metadata.
+	// More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of IngressClass objects.
+	Items []IngressClass `json:"items"`
+}
+
+// IngressClassSpec describes what an Ingress implementation supports.
+type IngressClassSpec struct {
+	// Capabilities lists the optional features of the Ingress API that the
+	// implementation supports, e.g. `RewriteHost`.  The features are named
+	// by the Feature constants of knative.dev/networking/pkg/ingress.
+	// +optional
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// IngressClassStatus will reflect Ready=True if the implementation behind the
+// IngressClass is installed and serving.
+type IngressClassStatus struct {
+	duckv1.Status `json:",inline"`
+}
+
+// GetStatus retrieves the status of the IngressClass. Implements the KRShaped interface.
+func (t *IngressClass) GetStatus() *duckv1.Status {
+	return &t.Status.Status
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+// Validate inspects and validates IngressClass object.
+func (c *IngressClass) Validate(ctx context.Context) *apis.FieldError {
+	return c.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec")
+}
+
+// Validate inspects and validates IngressClassSpec object.
+func (spec *IngressClassSpec) Validate(ctx context.Context) *apis.FieldError {
+	var all *apis.FieldError
+	seen := sets.NewString()
+	for idx, capability := range spec.Capabilities {
+		switch {
+		case capability == "":
+			all = all.Also(apis.ErrInvalidArrayValue(capability, "capabilities", idx))
+		case seen.Has(capability):
+			all = all.Also(apis.ErrGeneric("duplicate capability", apis.CurrentField).
+				ViaFieldIndex("capabilities", idx))
+		}
+		seen.Insert(capability)
+	}
+	return all
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestIngressClassSpecValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		cs   IngressClassSpec
+		want *apis.FieldError
+	}{{
+		name: "no capabilities",
+		cs:   IngressClassSpec{},
+	}, {
+		name: "capabilities",
+		cs: IngressClassSpec{
+			Capabilities: []string{"RewriteHost", "Timeout"},
+		},
+	}, {
+		name: "invalid capabilities",
+		cs: IngressClassSpec{
+			Capabilities: []string{"RewriteHost", "", "RewriteHost"},
+		},
+		want: apis.ErrInvalidArrayValue("", "spec.capabilities", 1).Also(
+			apis.ErrGeneric("duplicate capability", "spec.capabilities[2]")),
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := IngressClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "test.ingress.networking.knative.dev"},
+				Spec:       test.cs,
+			}
+			got := c.Validate(context.Background())
+			if !cmp.Equal(test.want.Error(), got.Error()) {
+				t.Errorf("Validate (-want, +got) = \n%s", cmp.Diff(test.want.Error(), got.Error()))
+			}
+		})
+	}
+}
@@ -48,6 +48,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&DomainList{},
 		&Ingress{},
 		&IngressList{},
+		&IngressClass{},
+		&IngressClassList{},
 		&Realm{},
 		&RealmList{},
 		&ServerlessService{},
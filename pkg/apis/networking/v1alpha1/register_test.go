@@ -28,6 +28,9 @@ func TestRegisterHelpers(t *testing.T) {
 	}{{
 		kind: "Ingress",
 		want: "Ingress.networking.internal.knative.dev",
+	}, {
+		kind: "IngressClass",
+		want: "IngressClass.networking.internal.knative.dev",
 	}, {
 		kind: "ServerlessService",
 		want: "ServerlessService.networking.internal.knative.dev",
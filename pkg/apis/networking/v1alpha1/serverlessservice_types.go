@@ -57,6 +57,9 @@ var (
 	_ apis.Validatable = (*ServerlessService)(nil)
 	_ apis.Defaultable = (*ServerlessService)(nil)
 
+	// Check that ServerlessService can be converted to other versions.
+	_ apis.Convertible = (*ServerlessService)(nil)
+
 	// Check that we can create OwnerReferences to a ServerlessService.
 	_ kmeta.OwnerRefable = (*ServerlessService)(nil)
 
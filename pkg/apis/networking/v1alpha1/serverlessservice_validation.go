@@ -52,5 +52,10 @@ func (spec *ServerlessServiceSpec) Validate(ctx context.Context) *apis.FieldErro
 
 	all = all.Also(networking.ValidateNamespacedObjectReference(&spec.ObjectRef).ViaField("objectRef"))
 
+	// ServerlessServices only expose the ports of the http1 and h2c protocols.
+	switch spec.ProtocolType {
+	case networking.ProtocolH2, networking.ProtocolGRPC:
+		return all.Also(apis.ErrInvalidValue(spec.ProtocolType, "protocolType"))
+	}
 	return all.Also(spec.ProtocolType.Validate(ctx).ViaField("protocolType"))
 }
@@ -94,6 +94,18 @@ func TestServerlessServiceSpecValidation(t *testing.T) {
 			ProtocolType: networking.ProtocolType("gRPC"),
 		},
 		want: apis.ErrInvalidValue("gRPC", "protocolType"),
+	}, {
+		name: "unsupported protocol",
+		skss: &ServerlessServiceSpec{
+			Mode: SKSOperationModeServe,
+			ObjectRef: corev1.ObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "foo",
+			},
+			ProtocolType: networking.ProtocolGRPC,
+		},
+		want: apis.ErrInvalidValue(networking.ProtocolGRPC, "protocolType"),
 	}, {
 		name: "wrong mode",
 		skss: &ServerlessServiceSpec{
@@ -0,0 +1,76 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/pkg/apis"
+)
+
+// ConvertTo implements apis.Convertible.
+// Converts source (from v1beta1.Certificate) into v1alpha1.Certificate.
+func (source *Certificate) ConvertTo(ctx context.Context, to apis.Convertible) error {
+	switch sink := to.(type) {
+	case *v1alpha1.Certificate:
+		sink.ObjectMeta = source.ObjectMeta
+		sink.Spec = v1alpha1.CertificateSpec(source.Spec)
+		source.Status.ConvertTo(ctx, &sink.Status)
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", sink)
+	}
+}
+
+// ConvertFrom implements apis.Convertible.
+// Converts obj from v1alpha1.Certificate into v1beta1.Certificate.
+func (sink *Certificate) ConvertFrom(ctx context.Context, from apis.Convertible) error {
+	switch source := from.(type) {
+	case *v1alpha1.Certificate:
+		sink.ObjectMeta = source.ObjectMeta
+		sink.Spec = CertificateSpec(source.Spec)
+		sink.Status.ConvertFrom(ctx, &source.Status)
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", source)
+	}
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *CertificateStatus) ConvertTo(ctx context.Context, sink *v1alpha1.CertificateStatus) {
+	sink.Status = source.Status
+	sink.NotAfter = source.NotAfter
+	if source.HTTP01Challenges != nil {
+		sink.HTTP01Challenges = make([]v1alpha1.HTTP01Challenge, len(source.HTTP01Challenges))
+		for i, c := range source.HTTP01Challenges {
+			sink.HTTP01Challenges[i] = v1alpha1.HTTP01Challenge(c)
+		}
+	}
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *CertificateStatus) ConvertFrom(ctx context.Context, source *v1alpha1.CertificateStatus) {
+	sink.Status = source.Status
+	sink.NotAfter = source.NotAfter
+	if source.HTTP01Challenges != nil {
+		sink.HTTP01Challenges = make([]HTTP01Challenge, len(source.HTTP01Challenges))
+		for i, c := range source.HTTP01Challenges {
+			sink.HTTP01Challenges[i] = HTTP01Challenge(c)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1beta1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+// withStorageBaseline returns a context whose update baseline, if any, is
+// converted into sink, so that it can be used to validate the v1alpha1 form
+// of a resource.
+func withStorageBaseline(ctx context.Context, sink apis.Convertible) context.Context {
+	if !apis.IsInUpdate(ctx) {
+		return ctx
+	}
+	base, ok := apis.GetBaseline(ctx).(apis.Convertible)
+	if !ok || base.ConvertTo(ctx, sink) != nil {
+		return ctx
+	}
+	return apis.WithinUpdate(ctx, sink)
+}
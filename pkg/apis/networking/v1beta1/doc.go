@@ -0,0 +1,37 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +k8s:deepcopy-gen=package
+// +groupName=networking.internal.knative.dev
+
+// Package v1beta1 is the v1beta1 version of the networking API.  It matches
+// v1alpha1 without the deprecated fields, and converts to and from v1alpha1,
+// which remains the storage version.  v1alpha1 can't convert to v1beta1, so
+// v1beta1 must be the HubVersion of the conversion webhook:
+//
+//	conversion.NewConversionController(ctx, "/resource-conversion",
+//		map[schema.GroupKind]conversion.GroupKindConversion{
+//			v1beta1.Kind("Ingress"): {
+//				DefinitionName: "ingresses.networking.internal.knative.dev",
+//				HubVersion:     "v1beta1",
+//				Zygotes: map[string]conversion.ConvertibleObject{
+//					"v1alpha1": &v1alpha1.Ingress{},
+//					"v1beta1":  &v1beta1.Ingress{},
+//				},
+//			},
+//			...
+//		}, ...)
+package v1beta1
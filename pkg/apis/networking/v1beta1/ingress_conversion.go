@@ -0,0 +1,490 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/pkg/apis"
+)
+
+// ConvertTo implements apis.Convertible.
+// Converts source (from v1beta1.Ingress) into v1alpha1.Ingress.
+func (source *Ingress) ConvertTo(ctx context.Context, to apis.Convertible) error {
+	switch sink := to.(type) {
+	case *v1alpha1.Ingress:
+		sink.ObjectMeta = source.ObjectMeta
+		source.Spec.ConvertTo(ctx, &sink.Spec)
+		source.Status.ConvertTo(ctx, &sink.Status)
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", sink)
+	}
+}
+
+// ConvertFrom implements apis.Convertible.
+// Converts obj from v1alpha1.Ingress into v1beta1.Ingress, dropping the
+// deprecated fields.
+func (sink *Ingress) ConvertFrom(ctx context.Context, from apis.Convertible) error {
+	switch source := from.(type) {
+	case *v1alpha1.Ingress:
+		sink.ObjectMeta = source.ObjectMeta
+		sink.Spec.ConvertFrom(ctx, &source.Spec)
+		sink.Status.ConvertFrom(ctx, &source.Status)
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", source)
+	}
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *IngressSpec) ConvertTo(ctx context.Context, sink *v1alpha1.IngressSpec) {
+	if source.TLS != nil {
+		sink.TLS = make([]v1alpha1.IngressTLS, len(source.TLS))
+		for i := range source.TLS {
+			source.TLS[i].ConvertTo(ctx, &sink.TLS[i])
+		}
+	}
+	if source.Rules != nil {
+		sink.Rules = make([]v1alpha1.IngressRule, len(source.Rules))
+		for i := range source.Rules {
+			source.Rules[i].ConvertTo(ctx, &sink.Rules[i])
+		}
+	}
+	if source.AccessLog != nil {
+		sink.AccessLog = &v1alpha1.AccessLog{}
+		source.AccessLog.ConvertTo(ctx, sink.AccessLog)
+	}
+	if source.DefaultBackend != nil {
+		sink.DefaultBackend = &v1alpha1.IngressBackend{}
+		source.DefaultBackend.ConvertTo(ctx, sink.DefaultBackend)
+	}
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *IngressSpec) ConvertFrom(ctx context.Context, source *v1alpha1.IngressSpec) {
+	if source.TLS != nil {
+		sink.TLS = make([]IngressTLS, len(source.TLS))
+		for i := range source.TLS {
+			sink.TLS[i].ConvertFrom(ctx, &source.TLS[i])
+		}
+	}
+	if source.Rules != nil {
+		sink.Rules = make([]IngressRule, len(source.Rules))
+		for i := range source.Rules {
+			sink.Rules[i].ConvertFrom(ctx, &source.Rules[i])
+		}
+	}
+	if source.AccessLog != nil {
+		sink.AccessLog = &AccessLog{}
+		sink.AccessLog.ConvertFrom(ctx, source.AccessLog)
+	}
+	if source.DefaultBackend != nil {
+		sink.DefaultBackend = &IngressBackend{}
+		sink.DefaultBackend.ConvertFrom(ctx, source.DefaultBackend)
+	}
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *AccessLog) ConvertTo(ctx context.Context, sink *v1alpha1.AccessLog) {
+	sink.Enabled = source.Enabled
+	sink.Format = source.Format
+	sink.RequestHeaders = source.RequestHeaders
+	sink.ResponseHeaders = source.ResponseHeaders
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *AccessLog) ConvertFrom(ctx context.Context, source *v1alpha1.AccessLog) {
+	sink.Enabled = source.Enabled
+	sink.Format = source.Format
+	sink.RequestHeaders = source.RequestHeaders
+	sink.ResponseHeaders = source.ResponseHeaders
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *IngressTLS) ConvertTo(ctx context.Context, sink *v1alpha1.IngressTLS) {
+	sink.Hosts = source.Hosts
+	sink.SecretName = source.SecretName
+	sink.SecretNamespace = source.SecretNamespace
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *IngressTLS) ConvertFrom(ctx context.Context, source *v1alpha1.IngressTLS) {
+	sink.Hosts = source.Hosts
+	sink.SecretName = source.SecretName
+	sink.SecretNamespace = source.SecretNamespace
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *IngressRule) ConvertTo(ctx context.Context, sink *v1alpha1.IngressRule) {
+	sink.Hosts = source.Hosts
+	sink.Visibility = v1alpha1.IngressVisibility(source.Visibility)
+	sink.AllowedSourceRanges = source.AllowedSourceRanges
+	sink.DeniedSourceRanges = source.DeniedSourceRanges
+	if source.ErrorResponses != nil {
+		sink.ErrorResponses = make([]v1alpha1.ErrorResponse, len(source.ErrorResponses))
+		for i := range source.ErrorResponses {
+			source.ErrorResponses[i].ConvertTo(ctx, &sink.ErrorResponses[i])
+		}
+	}
+	sink.Port = source.Port
+	sink.Protocol = v1alpha1.ListenerProtocol(source.Protocol)
+	if source.DefaultBackend != nil {
+		sink.DefaultBackend = &v1alpha1.IngressBackend{}
+		source.DefaultBackend.ConvertTo(ctx, sink.DefaultBackend)
+	}
+	if source.HTTP != nil {
+		sink.HTTP = &v1alpha1.HTTPIngressRuleValue{}
+		source.HTTP.ConvertTo(ctx, sink.HTTP)
+	}
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *IngressRule) ConvertFrom(ctx context.Context, source *v1alpha1.IngressRule) {
+	sink.Hosts = source.Hosts
+	sink.Visibility = IngressVisibility(source.Visibility)
+	sink.AllowedSourceRanges = source.AllowedSourceRanges
+	sink.DeniedSourceRanges = source.DeniedSourceRanges
+	if source.ErrorResponses != nil {
+		sink.ErrorResponses = make([]ErrorResponse, len(source.ErrorResponses))
+		for i := range source.ErrorResponses {
+			sink.ErrorResponses[i].ConvertFrom(ctx, &source.ErrorResponses[i])
+		}
+	}
+	sink.Port = source.Port
+	sink.Protocol = ListenerProtocol(source.Protocol)
+	if source.DefaultBackend != nil {
+		sink.DefaultBackend = &IngressBackend{}
+		sink.DefaultBackend.ConvertFrom(ctx, source.DefaultBackend)
+	}
+	if source.HTTP != nil {
+		sink.HTTP = &HTTPIngressRuleValue{}
+		sink.HTTP.ConvertFrom(ctx, source.HTTP)
+	}
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *ErrorResponse) ConvertTo(ctx context.Context, sink *v1alpha1.ErrorResponse) {
+	sink.StatusCodes = source.StatusCodes
+	sink.Body = v1alpha1.ConfigMapKeyReference(source.Body)
+	sink.ContentType = source.ContentType
+	sink.StatusCode = source.StatusCode
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *ErrorResponse) ConvertFrom(ctx context.Context, source *v1alpha1.ErrorResponse) {
+	sink.StatusCodes = source.StatusCodes
+	sink.Body = ConfigMapKeyReference(source.Body)
+	sink.ContentType = source.ContentType
+	sink.StatusCode = source.StatusCode
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *HTTPIngressRuleValue) ConvertTo(ctx context.Context, sink *v1alpha1.HTTPIngressRuleValue) {
+	if source.Paths != nil {
+		sink.Paths = make([]v1alpha1.HTTPIngressPath, len(source.Paths))
+		for i := range source.Paths {
+			source.Paths[i].ConvertTo(ctx, &sink.Paths[i])
+		}
+	}
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *HTTPIngressRuleValue) ConvertFrom(ctx context.Context, source *v1alpha1.HTTPIngressRuleValue) {
+	if source.Paths != nil {
+		sink.Paths = make([]HTTPIngressPath, len(source.Paths))
+		for i := range source.Paths {
+			sink.Paths[i].ConvertFrom(ctx, &source.Paths[i])
+		}
+	}
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *HTTPIngressPath) ConvertTo(ctx context.Context, sink *v1alpha1.HTTPIngressPath) {
+	sink.Path = source.Path
+	sink.Priority = source.Priority
+	sink.RewriteHost = source.RewriteHost
+	if source.Headers != nil {
+		sink.Headers = make(map[string]v1alpha1.HeaderMatch, len(source.Headers))
+		for k, v := range source.Headers {
+			sink.Headers[k] = v1alpha1.HeaderMatch(v)
+		}
+	}
+	if source.Splits != nil {
+		sink.Splits = make([]v1alpha1.IngressBackendSplit, len(source.Splits))
+		for i := range source.Splits {
+			source.Splits[i].ConvertTo(ctx, &sink.Splits[i])
+		}
+	}
+	sink.AppendHeaders = source.AppendHeaders
+	sink.Timeout = source.Timeout
+	if source.JWT != nil {
+		sink.JWT = &v1alpha1.JWTAuthentication{}
+		source.JWT.ConvertTo(ctx, sink.JWT)
+	}
+	if source.ExtAuthz != nil {
+		sink.ExtAuthz = &v1alpha1.ExtAuthz{}
+		source.ExtAuthz.ConvertTo(ctx, sink.ExtAuthz)
+	}
+	if source.Compression != nil {
+		sink.Compression = &v1alpha1.Compression{}
+		source.Compression.ConvertTo(ctx, sink.Compression)
+	}
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *HTTPIngressPath) ConvertFrom(ctx context.Context, source *v1alpha1.HTTPIngressPath) {
+	sink.Path = source.Path
+	sink.Priority = source.Priority
+	sink.RewriteHost = source.RewriteHost
+	if source.Headers != nil {
+		sink.Headers = make(map[string]HeaderMatch, len(source.Headers))
+		for k, v := range source.Headers {
+			sink.Headers[k] = HeaderMatch(v)
+		}
+	}
+	if source.Splits != nil {
+		sink.Splits = make([]IngressBackendSplit, len(source.Splits))
+		for i := range source.Splits {
+			sink.Splits[i].ConvertFrom(ctx, &source.Splits[i])
+		}
+	}
+	sink.AppendHeaders = source.AppendHeaders
+	sink.Timeout = source.Timeout
+	if source.JWT != nil {
+		sink.JWT = &JWTAuthentication{}
+		sink.JWT.ConvertFrom(ctx, source.JWT)
+	}
+	if source.ExtAuthz != nil {
+		sink.ExtAuthz = &ExtAuthz{}
+		sink.ExtAuthz.ConvertFrom(ctx, source.ExtAuthz)
+	}
+	if source.Compression != nil {
+		sink.Compression = &Compression{}
+		sink.Compression.ConvertFrom(ctx, source.Compression)
+	}
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *Compression) ConvertTo(ctx context.Context, sink *v1alpha1.Compression) {
+	if source.Algorithms != nil {
+		sink.Algorithms = make([]v1alpha1.CompressionAlgorithm, len(source.Algorithms))
+		for i, a := range source.Algorithms {
+			sink.Algorithms[i] = v1alpha1.CompressionAlgorithm(a)
+		}
+	}
+	sink.MinimumSize = source.MinimumSize
+	sink.ContentTypes = source.ContentTypes
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *Compression) ConvertFrom(ctx context.Context, source *v1alpha1.Compression) {
+	if source.Algorithms != nil {
+		sink.Algorithms = make([]CompressionAlgorithm, len(source.Algorithms))
+		for i, a := range source.Algorithms {
+			sink.Algorithms[i] = CompressionAlgorithm(a)
+		}
+	}
+	sink.MinimumSize = source.MinimumSize
+	sink.ContentTypes = source.ContentTypes
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *JWTAuthentication) ConvertTo(ctx context.Context, sink *v1alpha1.JWTAuthentication) {
+	sink.Issuers = source.Issuers
+	sink.Audiences = source.Audiences
+	sink.JWKS.Inline = source.JWKS.Inline
+	if source.JWKS.SecretRef != nil {
+		ref := v1alpha1.SecretKeyReference(*source.JWKS.SecretRef)
+		sink.JWKS.SecretRef = &ref
+	}
+	sink.FromHeader = source.FromHeader
+	sink.FromCookie = source.FromCookie
+	sink.ForwardClaims = source.ForwardClaims
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *JWTAuthentication) ConvertFrom(ctx context.Context, source *v1alpha1.JWTAuthentication) {
+	sink.Issuers = source.Issuers
+	sink.Audiences = source.Audiences
+	sink.JWKS.Inline = source.JWKS.Inline
+	if source.JWKS.SecretRef != nil {
+		ref := SecretKeyReference(*source.JWKS.SecretRef)
+		sink.JWKS.SecretRef = &ref
+	}
+	sink.FromHeader = source.FromHeader
+	sink.FromCookie = source.FromCookie
+	sink.ForwardClaims = source.ForwardClaims
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *ExtAuthz) ConvertTo(ctx context.Context, sink *v1alpha1.ExtAuthz) {
+	source.Service.ConvertTo(ctx, &sink.Service)
+	sink.RequestHeaders = source.RequestHeaders
+	sink.UpstreamHeaders = source.UpstreamHeaders
+	sink.Timeout = source.Timeout
+	sink.FailureMode = v1alpha1.ExtAuthzFailureMode(source.FailureMode)
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *ExtAuthz) ConvertFrom(ctx context.Context, source *v1alpha1.ExtAuthz) {
+	sink.Service.ConvertFrom(ctx, &source.Service)
+	sink.RequestHeaders = source.RequestHeaders
+	sink.UpstreamHeaders = source.UpstreamHeaders
+	sink.Timeout = source.Timeout
+	sink.FailureMode = ExtAuthzFailureMode(source.FailureMode)
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *IngressBackendSplit) ConvertTo(ctx context.Context, sink *v1alpha1.IngressBackendSplit) {
+	source.IngressBackend.ConvertTo(ctx, &sink.IngressBackend)
+	sink.Percent = source.Percent
+	sink.AppendHeaders = source.AppendHeaders
+	if source.Locality != nil {
+		sink.Locality = &v1alpha1.LocalityPreferences{}
+		source.Locality.ConvertTo(ctx, sink.Locality)
+	}
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *IngressBackendSplit) ConvertFrom(ctx context.Context, source *v1alpha1.IngressBackendSplit) {
+	sink.IngressBackend.ConvertFrom(ctx, &source.IngressBackend)
+	sink.Percent = source.Percent
+	sink.AppendHeaders = source.AppendHeaders
+	if source.Locality != nil {
+		sink.Locality = &LocalityPreferences{}
+		sink.Locality.ConvertFrom(ctx, source.Locality)
+	}
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *LocalityPreferences) ConvertTo(ctx context.Context, sink *v1alpha1.LocalityPreferences) {
+	sink.PreferSameZone = source.PreferSameZone
+	sink.FailoverZones = source.FailoverZones
+	if source.Distribution != nil {
+		sink.Distribution = make([]v1alpha1.LocalityDistribution, len(source.Distribution))
+		for i, d := range source.Distribution {
+			sink.Distribution[i] = v1alpha1.LocalityDistribution(d)
+		}
+	}
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *LocalityPreferences) ConvertFrom(ctx context.Context, source *v1alpha1.LocalityPreferences) {
+	sink.PreferSameZone = source.PreferSameZone
+	sink.FailoverZones = source.FailoverZones
+	if source.Distribution != nil {
+		sink.Distribution = make([]LocalityDistribution, len(source.Distribution))
+		for i, d := range source.Distribution {
+			sink.Distribution[i] = LocalityDistribution(d)
+		}
+	}
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *IngressBackend) ConvertTo(ctx context.Context, sink *v1alpha1.IngressBackend) {
+	*sink = v1alpha1.IngressBackend(*source)
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *IngressBackend) ConvertFrom(ctx context.Context, source *v1alpha1.IngressBackend) {
+	*sink = IngressBackend(*source)
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *IngressStatus) ConvertTo(ctx context.Context, sink *v1alpha1.IngressStatus) {
+	sink.Status = source.Status
+	if source.PublicLoadBalancer != nil {
+		sink.PublicLoadBalancer = &v1alpha1.LoadBalancerStatus{}
+		source.PublicLoadBalancer.ConvertTo(ctx, sink.PublicLoadBalancer)
+	}
+	if source.PrivateLoadBalancer != nil {
+		sink.PrivateLoadBalancer = &v1alpha1.LoadBalancerStatus{}
+		source.PrivateLoadBalancer.ConvertTo(ctx, sink.PrivateLoadBalancer)
+	}
+	if source.Rules != nil {
+		sink.Rules = make([]v1alpha1.IngressRuleStatus, len(source.Rules))
+		for i := range source.Rules {
+			source.Rules[i].ConvertTo(ctx, &sink.Rules[i])
+		}
+	}
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *IngressStatus) ConvertFrom(ctx context.Context, source *v1alpha1.IngressStatus) {
+	sink.Status = source.Status
+	if source.PublicLoadBalancer != nil {
+		sink.PublicLoadBalancer = &LoadBalancerStatus{}
+		sink.PublicLoadBalancer.ConvertFrom(ctx, source.PublicLoadBalancer)
+	}
+	if source.PrivateLoadBalancer != nil {
+		sink.PrivateLoadBalancer = &LoadBalancerStatus{}
+		sink.PrivateLoadBalancer.ConvertFrom(ctx, source.PrivateLoadBalancer)
+	}
+	if source.Rules != nil {
+		sink.Rules = make([]IngressRuleStatus, len(source.Rules))
+		for i := range source.Rules {
+			sink.Rules[i].ConvertFrom(ctx, &source.Rules[i])
+		}
+	}
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *IngressRuleStatus) ConvertTo(ctx context.Context, sink *v1alpha1.IngressRuleStatus) {
+	sink.Host = source.Host
+	sink.Ready = source.Ready
+	sink.URL = source.URL
+	if source.LoadBalancer != nil {
+		lb := v1alpha1.LoadBalancerIngressStatus(*source.LoadBalancer)
+		sink.LoadBalancer = &lb
+	}
+	sink.LastProbeError = source.LastProbeError
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *IngressRuleStatus) ConvertFrom(ctx context.Context, source *v1alpha1.IngressRuleStatus) {
+	sink.Host = source.Host
+	sink.Ready = source.Ready
+	sink.URL = source.URL
+	if source.LoadBalancer != nil {
+		lb := LoadBalancerIngressStatus(*source.LoadBalancer)
+		sink.LoadBalancer = &lb
+	}
+	sink.LastProbeError = source.LastProbeError
+}
+
+// ConvertTo converts the receiver into `sink`.
+func (source *LoadBalancerStatus) ConvertTo(ctx context.Context, sink *v1alpha1.LoadBalancerStatus) {
+	if source.Ingress != nil {
+		sink.Ingress = make([]v1alpha1.LoadBalancerIngressStatus, len(source.Ingress))
+		for i, lb := range source.Ingress {
+			sink.Ingress[i] = v1alpha1.LoadBalancerIngressStatus(lb)
+		}
+	}
+}
+
+// ConvertFrom converts `source` into the receiver.
+func (sink *LoadBalancerStatus) ConvertFrom(ctx context.Context, source *v1alpha1.LoadBalancerStatus) {
+	if source.Ingress != nil {
+		sink.Ingress = make([]LoadBalancerIngressStatus, len(source.Ingress))
+		for i, lb := range source.Ingress {
+			sink.Ingress[i] = LoadBalancerIngressStatus(lb)
+		}
+	}
+}
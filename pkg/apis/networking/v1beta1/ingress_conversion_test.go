@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+)
+
+func TestIngressConversionBadType(t *testing.T) {
+	good, bad := &Ingress{}, &v1alpha1.Certificate{}
+
+	if err := good.ConvertTo(context.Background(), bad); err == nil {
+		t.Errorf("ConvertTo() = %#v, wanted error", bad)
+	}
+
+	if err := good.ConvertFrom(context.Background(), bad); err == nil {
+		t.Errorf("ConvertFrom() = %#v, wanted error", good)
+	}
+}
+
+func TestIngressConversionRoundTrip(t *testing.T) {
+	f := newFuzzer(t)
+	for i := 0; i < fuzzRounds; i++ {
+		want := &Ingress{}
+		f.Fuzz(want)
+
+		storage := &v1alpha1.Ingress{}
+		if err := want.ConvertTo(context.Background(), storage); err != nil {
+			t.Fatal("ConvertTo() =", err)
+		}
+		got := &Ingress{}
+		if err := got.ConvertFrom(context.Background(), storage); err != nil {
+			t.Fatal("ConvertFrom() =", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatal("Round trip (-want, +got) =", diff)
+		}
+	}
+}
+
+func TestIngressConversionFromStorage(t *testing.T) {
+	f := newFuzzer(t)
+	for i := 0; i < fuzzRounds; i++ {
+		want := &v1alpha1.Ingress{}
+		f.Fuzz(want)
+
+		ing := &Ingress{}
+		if err := ing.ConvertFrom(context.Background(), want); err != nil {
+			t.Fatal("ConvertFrom() =", err)
+		}
+		got := &v1alpha1.Ingress{}
+		if err := ing.ConvertTo(context.Background(), got); err != nil {
+			t.Fatal("ConvertTo() =", err)
+		}
+
+		// The deprecated fields don't exist in v1beta1.
+		want.Spec.DeprecatedGeneration = 0
+		want.Spec.DeprecatedVisibility = ""
+		for i := range want.Spec.TLS {
+			want.Spec.TLS[i].DeprecatedServerCertificate = ""
+			want.Spec.TLS[i].DeprecatedPrivateKey = ""
+		}
+		for _, rule := range want.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for i := range rule.HTTP.Paths {
+				rule.HTTP.Paths[i].DeprecatedRetries = nil
+			}
+		}
+		want.Status.DeprecatedLoadBalancer = nil
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatal("Round trip (-want, +got) =", diff)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestIngressDefaulting(t *testing.T) {
+	backend := IngressBackend{
+		ServiceName:      "revision-000",
+		ServiceNamespace: "default",
+		ServicePort:      intstr.FromInt(8080),
+	}
+	got := &Ingress{
+		Spec: IngressSpec{
+			Rules: []IngressRule{{
+				Hosts: []string{"example.com"},
+				Port:  9000,
+				HTTP: &HTTPIngressRuleValue{
+					Paths: []HTTPIngressPath{{
+						Splits: []IngressBackendSplit{{
+							IngressBackend: backend,
+						}},
+					}},
+				},
+			}},
+		},
+	}
+	want := &Ingress{
+		Spec: IngressSpec{
+			Rules: []IngressRule{{
+				Hosts:      []string{"example.com"},
+				Visibility: IngressVisibilityExternalIP,
+				Port:       9000,
+				Protocol:   ListenerProtocolHTTP,
+				HTTP: &HTTPIngressRuleValue{
+					Paths: []HTTPIngressPath{{
+						Splits: []IngressBackendSplit{{
+							IngressBackend: backend,
+							Percent:        100,
+						}},
+					}},
+				},
+			}},
+		},
+	}
+
+	got.SetDefaults(context.Background())
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SetDefaults (-want, +got) = %v", diff)
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+var ingressCondSet = apis.NewLivingConditionSet(
+	IngressConditionNetworkConfigured,
+	IngressConditionLoadBalancerReady,
+	IngressConditionFeatureSupported,
+)
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*Ingress) GetConditionSet() apis.ConditionSet {
+	return ingressCondSet
+}
+
+// GetGroupVersionKind returns SchemeGroupVersion of an Ingress
+func (i *Ingress) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("Ingress")
+}
+
+// GetCondition returns the current condition of a given condition type
+func (is *IngressStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return ingressCondSet.Manage(is).GetCondition(t)
+}
+
+// InitializeConditions initializes conditions of an IngressStatus
+func (is *IngressStatus) InitializeConditions() {
+	is.initializeFeatureSupported()
+	ingressCondSet.Manage(is).InitializeConditions()
+}
+
+// MarkNetworkConfigured set IngressConditionNetworkConfigured in IngressStatus as true
+func (is *IngressStatus) MarkNetworkConfigured() {
+	is.initializeFeatureSupported()
+	ingressCondSet.Manage(is).MarkTrue(IngressConditionNetworkConfigured)
+}
+
+// MarkResourceNotOwned changes the "NetworkConfigured" condition to false to reflect that the
+// resource of the given kind and name has already been created, and we do not own it.
+func (is *IngressStatus) MarkResourceNotOwned(kind, name string) {
+	ingressCondSet.Manage(is).MarkFalse(IngressConditionNetworkConfigured, "NotOwned",
+		fmt.Sprintf("There is an existing %s %q that we do not own.", kind, name))
+}
+
+// MarkLoadBalancerReady marks the Ingress with IngressConditionLoadBalancerReady,
+// and also populate the address of the load balancer.
+func (is *IngressStatus) MarkLoadBalancerReady(publicLbs []LoadBalancerIngressStatus, privateLbs []LoadBalancerIngressStatus) {
+	is.PublicLoadBalancer = &LoadBalancerStatus{Ingress: publicLbs}
+	is.PrivateLoadBalancer = &LoadBalancerStatus{Ingress: privateLbs}
+
+	is.initializeFeatureSupported()
+	ingressCondSet.Manage(is).MarkTrue(IngressConditionLoadBalancerReady)
+}
+
+// MarkLoadBalancerNotReady marks the "IngressConditionLoadBalancerReady" condition to unknown to
+// reflect that the load balancer is not ready yet.
+func (is *IngressStatus) MarkLoadBalancerNotReady() {
+	ingressCondSet.Manage(is).MarkUnknown(IngressConditionLoadBalancerReady, "Uninitialized",
+		"Waiting for load balancer to be ready")
+}
+
+// MarkLoadBalancerNotReadyWithMessage is like MarkLoadBalancerNotReady, but
+// describes why the load balancer is not ready yet with the given message.
+func (is *IngressStatus) MarkLoadBalancerNotReadyWithMessage(message string) {
+	ingressCondSet.Manage(is).MarkUnknown(IngressConditionLoadBalancerReady, "Uninitialized", message)
+}
+
+// MarkLoadBalancerFailed marks the "IngressConditionLoadBalancerReady" condition to false.
+func (is *IngressStatus) MarkLoadBalancerFailed(reason, message string) {
+	ingressCondSet.Manage(is).MarkFalse(IngressConditionLoadBalancerReady, reason, message)
+}
+
+// MarkFeaturesSupported marks the "IngressConditionFeatureSupported" condition
+// to true to reflect that all the fields set on the Ingress are supported.
+func (is *IngressStatus) MarkFeaturesSupported() {
+	ingressCondSet.Manage(is).MarkTrue(IngressConditionFeatureSupported)
+}
+
+// initializeFeatureSupported marks the "IngressConditionFeatureSupported"
+// condition to true unless it is already set.  Implementations that don't check
+// the fields of the Ingress they support, or that set the conditions without
+// initializing them, must not be kept from becoming ready.
+func (is *IngressStatus) initializeFeatureSupported() {
+	if is.GetCondition(IngressConditionFeatureSupported) == nil {
+		ingressCondSet.Manage(is).MarkTrue(IngressConditionFeatureSupported)
+	}
+}
+
+// MarkUnsupportedFeature marks the "IngressConditionFeatureSupported" condition
+// to false to reflect that the given fields of the Ingress are not supported by
+// the Ingress implementation, e.g. "spec.rules[0].http.paths[0].rewriteHost".
+func (is *IngressStatus) MarkUnsupportedFeature(fields ...string) {
+	ingressCondSet.Manage(is).MarkFalse(IngressConditionFeatureSupported, IngressReasonFeatureNotSupported,
+		"The following fields are not supported by the Ingress implementation: %s", strings.Join(fields, ", "))
+}
+
+// InitializeRules sets up the status of the given hosts of the given
+// generation of the Ingress, keeping the status of those already present and
+// dropping the status of any other host.  Newly added hosts are not ready yet,
+// nor are the hosts already present when the generation differs from the
+// observed one, since they aren't served according to the new spec yet.
+func (is *IngressStatus) InitializeRules(generation int64, hosts ...string) {
+	existing := make(map[string]IngressRuleStatus, len(is.Rules))
+	for _, rs := range is.Rules {
+		existing[rs.Host] = rs
+	}
+	rules := make([]IngressRuleStatus, 0, len(hosts))
+	for _, host := range sets.NewString(hosts...).List() {
+		rs, ok := existing[host]
+		if !ok {
+			rs = IngressRuleStatus{Host: host, Ready: corev1.ConditionUnknown}
+		} else if generation != is.ObservedGeneration {
+			rs.Ready = corev1.ConditionUnknown
+		}
+		rules = append(rules, rs)
+	}
+	is.Rules = rules
+}
+
+// GetRuleStatus returns the status of the given host, or nil if there is none.
+func (is *IngressStatus) GetRuleStatus(host string) *IngressRuleStatus {
+	for i := range is.Rules {
+		if is.Rules[i].Host == host {
+			return &is.Rules[i]
+		}
+	}
+	return nil
+}
+
+// MarkRuleReady marks the given host as ready, served at url by the given load
+// balancer.
+func (is *IngressStatus) MarkRuleReady(host string, url *apis.URL, lb *LoadBalancerIngressStatus) {
+	rs := is.ruleStatus(host)
+	rs.Ready = corev1.ConditionTrue
+	rs.URL = url
+	rs.LoadBalancer = lb
+	rs.LastProbeError = ""
+}
+
+// MarkRuleNotReady marks the given host as not ready yet, recording probeErr
+// as its last probe error when it is not nil.
+func (is *IngressStatus) MarkRuleNotReady(host string, probeErr error) {
+	rs := is.ruleStatus(host)
+	rs.Ready = corev1.ConditionUnknown
+	if probeErr != nil {
+		rs.LastProbeError = probeErr.Error()
+	}
+}
+
+// ruleStatus returns the status of the given host, adding it if there is none.
+func (is *IngressStatus) ruleStatus(host string) *IngressRuleStatus {
+	if rs := is.GetRuleStatus(host); rs != nil {
+		return rs
+	}
+	idx := sort.Search(len(is.Rules), func(i int) bool {
+		return is.Rules[i].Host >= host
+	})
+	is.Rules = append(is.Rules, IngressRuleStatus{})
+	copy(is.Rules[idx+1:], is.Rules[idx:])
+	is.Rules[idx] = IngressRuleStatus{Host: host, Ready: corev1.ConditionUnknown}
+	return &is.Rules[idx]
+}
+
+// MarkIngressNotReady marks the "IngressConditionReady" condition to unknown.
+func (is *IngressStatus) MarkIngressNotReady(reason, message string) {
+	ingressCondSet.Manage(is).MarkUnknown(IngressConditionReady, reason, message)
+}
+
+// IsReady returns true if the Status condition MetricConditionReady
+// is true and the latest spec has been observed.
+func (i *Ingress) IsReady() bool {
+	is := i.Status
+	return is.ObservedGeneration == i.Generation &&
+		is.GetCondition(IngressConditionReady).IsTrue()
+}
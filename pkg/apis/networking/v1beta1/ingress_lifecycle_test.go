@@ -0,0 +1,286 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1beta1
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/apis/duck"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	apistest "knative.dev/pkg/apis/testing"
+)
+
+func TestIngressDuckTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		t    duck.Implementable
+	}{{
+		name: "conditions",
+		t:    &duckv1.Conditions{},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := duck.VerifyType(&Ingress{}, test.t)
+			if err != nil {
+				t.Errorf("VerifyType(Ingress, %T) = %v", test.t, err)
+			}
+		})
+	}
+}
+
+func TestIngressGetConditionSet(t *testing.T) {
+	r := &Ingress{}
+
+	if got, want := r.GetConditionSet().GetTopLevelConditionType(), apis.ConditionReady; got != want {
+		t.Errorf("GetConditionSet=%v, want=%v", got, want)
+	}
+}
+
+func TestIngressGetGroupVersionKind(t *testing.T) {
+	ci := Ingress{}
+	expected := SchemeGroupVersion.WithKind("Ingress")
+	if diff := cmp.Diff(expected, ci.GetGroupVersionKind()); diff != "" {
+		t.Errorf("Unexpected diff (-want, +got) = %v", diff)
+	}
+}
+
+func TestIngressTypicalFlow(t *testing.T) {
+	r := &IngressStatus{}
+	r.InitializeConditions()
+
+	apistest.CheckConditionOngoing(r, IngressConditionReady, t)
+
+	// Then network is configured.
+	r.MarkNetworkConfigured()
+	apistest.CheckConditionSucceeded(r, IngressConditionNetworkConfigured, t)
+	apistest.CheckConditionOngoing(r, IngressConditionReady, t)
+
+	// Then ingress is pending.
+	r.MarkLoadBalancerNotReady()
+	apistest.CheckConditionOngoing(r, IngressConditionLoadBalancerReady, t)
+	apistest.CheckConditionOngoing(r, IngressConditionReady, t)
+
+	const message = "1 of 2 Pods pending"
+	r.MarkLoadBalancerNotReadyWithMessage(message)
+	apistest.CheckConditionOngoing(r, IngressConditionLoadBalancerReady, t)
+	if got := r.GetCondition(IngressConditionLoadBalancerReady).Message; got != message {
+		t.Errorf("LoadBalancerReady message = %q, want: %q", got, message)
+	}
+
+	r.MarkLoadBalancerFailed("some reason", "some message")
+	apistest.CheckConditionFailed(r, IngressConditionLoadBalancerReady, t)
+	apistest.CheckConditionFailed(r, IngressConditionLoadBalancerReady, t)
+
+	// Then ingress has address.
+	r.MarkLoadBalancerReady(
+		[]LoadBalancerIngressStatus{{DomainInternal: "gateway.default.svc"}},
+		[]LoadBalancerIngressStatus{{DomainInternal: "private.gateway.default.svc"}},
+	)
+	apistest.CheckConditionSucceeded(r, IngressConditionLoadBalancerReady, t)
+	apistest.CheckConditionSucceeded(r, IngressConditionReady, t)
+	i := &Ingress{Status: *r}
+	if !i.IsReady() {
+		t.Fatal("IsReady()=false, wanted true")
+	}
+
+	// Mark not owned.
+	r.MarkResourceNotOwned("i own", "you")
+	apistest.CheckConditionFailed(r, IngressConditionReady, t)
+
+	// Mark network configured, and check that ingress is ready again
+	r.MarkNetworkConfigured()
+	apistest.CheckConditionSucceeded(r, IngressConditionReady, t)
+	i = &Ingress{Status: *r}
+	if !i.IsReady() {
+		t.Fatal("IsReady()=false, wanted true")
+	}
+
+	// Mark ingress not ready
+	r.MarkIngressNotReady("", "")
+	apistest.CheckConditionOngoing(r, IngressConditionReady, t)
+}
+
+func TestIngressReadyWithoutInitializeConditions(t *testing.T) {
+	// Reconcilers setting the conditions directly, without initializing them.
+	r := &IngressStatus{}
+	r.MarkNetworkConfigured()
+	r.MarkLoadBalancerReady(
+		[]LoadBalancerIngressStatus{{DomainInternal: "gateway.default.svc"}},
+		[]LoadBalancerIngressStatus{{DomainInternal: "private.gateway.default.svc"}},
+	)
+	apistest.CheckConditionSucceeded(r, IngressConditionFeatureSupported, t)
+	apistest.CheckConditionSucceeded(r, IngressConditionReady, t)
+
+	// In any order, and with an unsupported feature marked first.
+	r = &IngressStatus{}
+	r.MarkUnsupportedFeature("spec.accessLog")
+	r.MarkLoadBalancerReady(nil, nil)
+	r.MarkNetworkConfigured()
+	apistest.CheckConditionFailed(r, IngressConditionFeatureSupported, t)
+	apistest.CheckConditionFailed(r, IngressConditionReady, t)
+}
+
+func TestIngressFeatureSupported(t *testing.T) {
+	r := &IngressStatus{}
+	r.InitializeConditions()
+
+	// Features are assumed to be supported until told otherwise.
+	apistest.CheckConditionSucceeded(r, IngressConditionFeatureSupported, t)
+
+	r.MarkNetworkConfigured()
+	r.MarkLoadBalancerReady(
+		[]LoadBalancerIngressStatus{{DomainInternal: "gateway.default.svc"}},
+		[]LoadBalancerIngressStatus{{DomainInternal: "private.gateway.default.svc"}},
+	)
+	apistest.CheckConditionSucceeded(r, IngressConditionReady, t)
+
+	r.MarkUnsupportedFeature("spec.rules[0].http.paths[0].rewriteHost", "spec.accessLog")
+	apistest.CheckConditionFailed(r, IngressConditionFeatureSupported, t)
+	apistest.CheckConditionFailed(r, IngressConditionReady, t)
+	c := r.GetCondition(IngressConditionFeatureSupported)
+	if got, want := c.Reason, IngressReasonFeatureNotSupported; got != want {
+		t.Errorf("Reason = %q, wanted %q", got, want)
+	}
+	if got, want := c.Message, "The following fields are not supported by the Ingress implementation: "+
+		"spec.rules[0].http.paths[0].rewriteHost, spec.accessLog"; got != want {
+		t.Errorf("Message = %q, wanted %q", got, want)
+	}
+
+	// Initializing the conditions again keeps the failure.
+	r.InitializeConditions()
+	apistest.CheckConditionFailed(r, IngressConditionFeatureSupported, t)
+
+	r.MarkFeaturesSupported()
+	apistest.CheckConditionSucceeded(r, IngressConditionFeatureSupported, t)
+	apistest.CheckConditionSucceeded(r, IngressConditionReady, t)
+}
+
+func TestIngressRuleStatus(t *testing.T) {
+	r := &IngressStatus{}
+	r.InitializeRules(1, "foo.example.com", "bar.example.com", "foo.example.com")
+	r.ObservedGeneration = 1
+
+	want := []IngressRuleStatus{{
+		Host:  "bar.example.com",
+		Ready: corev1.ConditionUnknown,
+	}, {
+		Host:  "foo.example.com",
+		Ready: corev1.ConditionUnknown,
+	}}
+	if diff := cmp.Diff(want, r.Rules); diff != "" {
+		t.Error("InitializeRules (-want, +got) =", diff)
+	}
+
+	// Then a probe of foo fails.
+	r.MarkRuleNotReady("foo.example.com", errors.New("unexpected status code: want 200, got 503"))
+	// And bar becomes ready.
+	url := &apis.URL{Scheme: "http", Host: "bar.example.com"}
+	lb := &LoadBalancerIngressStatus{DomainInternal: "gateway.default.svc"}
+	r.MarkRuleReady("bar.example.com", url, lb)
+	// And a host we didn't know about shows up.
+	r.MarkRuleNotReady("baz.example.com", nil)
+
+	want = []IngressRuleStatus{{
+		Host:         "bar.example.com",
+		Ready:        corev1.ConditionTrue,
+		URL:          url,
+		LoadBalancer: lb,
+	}, {
+		Host:  "baz.example.com",
+		Ready: corev1.ConditionUnknown,
+	}, {
+		Host:           "foo.example.com",
+		Ready:          corev1.ConditionUnknown,
+		LastProbeError: "unexpected status code: want 200, got 503",
+	}}
+	if diff := cmp.Diff(want, r.Rules); diff != "" {
+		t.Error("Rules (-want, +got) =", diff)
+	}
+	if got := r.GetRuleStatus("baz.example.com"); got == nil || got.Ready != corev1.ConditionUnknown {
+		t.Errorf("GetRuleStatus(baz.example.com) = %v, wanted an Unknown status", got)
+	}
+
+	// Then foo becomes ready, which clears its probe error, and baz is removed.
+	r.MarkRuleReady("foo.example.com", url, lb)
+	r.InitializeRules(1, "foo.example.com", "bar.example.com")
+
+	want = []IngressRuleStatus{{
+		Host:         "bar.example.com",
+		Ready:        corev1.ConditionTrue,
+		URL:          url,
+		LoadBalancer: lb,
+	}, {
+		Host:         "foo.example.com",
+		Ready:        corev1.ConditionTrue,
+		URL:          url,
+		LoadBalancer: lb,
+	}}
+	if diff := cmp.Diff(want, r.Rules); diff != "" {
+		t.Error("Rules (-want, +got) =", diff)
+	}
+	if got := r.GetRuleStatus("baz.example.com"); got != nil {
+		t.Errorf("GetRuleStatus(baz.example.com) = %v, wanted nil", got)
+	}
+
+	// Then the spec changes, and foo isn't served according to it yet.
+	r.InitializeRules(2, "foo.example.com")
+
+	want = []IngressRuleStatus{{
+		Host:         "foo.example.com",
+		Ready:        corev1.ConditionUnknown,
+		URL:          url,
+		LoadBalancer: lb,
+	}}
+	if diff := cmp.Diff(want, r.Rules); diff != "" {
+		t.Error("Rules (-want, +got) =", diff)
+	}
+}
+
+func TestIngressGetCondition(t *testing.T) {
+	ingressStatus := &IngressStatus{}
+	ingressStatus.InitializeConditions()
+	tests := []struct {
+		name     string
+		condType apis.ConditionType
+		expect   *apis.Condition
+	}{{
+		name:     "random condition",
+		condType: apis.ConditionType("random"),
+		expect:   nil,
+	}, {
+		name:     "ready condition",
+		condType: apis.ConditionReady,
+		expect: &apis.Condition{
+			Status: corev1.ConditionUnknown,
+		},
+	}, {
+		name:     "succeeded condition",
+		condType: apis.ConditionSucceeded,
+		expect:   nil,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := ingressStatus.GetCondition(tc.condType), tc.expect; got != nil && got.Status != want.Status {
+				t.Errorf("got: %v, want: %v", got, want)
+			}
+		})
+	}
+}
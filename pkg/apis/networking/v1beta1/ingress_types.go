@@ -0,0 +1,742 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Ingress is a collection of rules that allow inbound connections to reach the endpoints defined
+// by a backend. An Ingress can be configured to give services externally-reachable URLs, load
+// balance traffic, offer name based virtual hosting, etc.
+//
+// This is heavily based on K8s Ingress https://godoc.org/k8s.io/api/networking/v1beta1#Ingress
+// which some highlighted modifications.
+type Ingress struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the Ingress.
+	// More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	// +optional
+	Spec IngressSpec `json:"spec,omitempty"`
+
+	// Status is the current state of the Ingress.
+	// More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	// +optional
+	Status IngressStatus `json:"status,omitempty"`
+}
+
+// Verify that Ingress adheres to the appropriate interfaces.
+var (
+	// Check that Ingress may be validated and defaulted.
+	_ apis.Validatable = (*Ingress)(nil)
+	_ apis.Defaultable = (*Ingress)(nil)
+
+	// Check that Ingress can be converted to other versions.
+	_ apis.Convertible = (*Ingress)(nil)
+
+	// Check that we can create OwnerReferences to a Ingress.
+	_ kmeta.OwnerRefable = (*Ingress)(nil)
+
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*Ingress)(nil)
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IngressList is a collection of Ingress objects.
+type IngressList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object metadata.
+	// More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of Ingress objects.
+	Items []Ingress `json:"items"`
+}
+
+// IngressSpec describes the Ingress the user wishes to exist.
+//
+// In general this follows the same shape as K8s Ingress.
+// Some notable differences:
+// - Backends now can have namespace:
+// - Traffic can be split across multiple backends.
+// - Timeout can be configured.
+// - Headers can be appended.
+type IngressSpec struct {
+	// TLS configuration. Currently Ingress only supports a single TLS
+	// port: 443. If multiple members of this list specify different hosts, they
+	// will be multiplexed on the same port according to the hostname specified
+	// through the SNI TLS extension, if the ingress controller fulfilling the
+	// ingress supports SNI.
+	// +optional
+	TLS []IngressTLS `json:"tls,omitempty"`
+
+	// A list of host rules used to configure the Ingress.
+	// +optional
+	Rules []IngressRule `json:"rules,omitempty"`
+
+	// AccessLog configures logging of the requests served by this Ingress.
+	// Unset fields take their cluster-wide defaults from the `config-network`
+	// ConfigMap.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	AccessLog *AccessLog `json:"accessLog,omitempty"`
+
+	// DefaultBackend is the backend receiving requests for any of the hosts of
+	// this Ingress whose path matches none of the HTTPIngressPaths of the
+	// matching rule, and which that rule's own DefaultBackend doesn't cover.
+	// It does not make the Ingress receive requests for hosts not listed in
+	// its rules.  When no default backend applies, such requests get a 404.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	DefaultBackend *IngressBackend `json:"defaultBackend,omitempty"`
+}
+
+// AccessLog describes how the requests served by an Ingress are logged.
+type AccessLog struct {
+	// Enabled specifies whether requests are logged.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Format is the golang text template used to format access log entries.
+	// Valid variables defined in the template include Host, Path, Status,
+	// Latency, SplitTarget, Tag, RequestHeaders and ResponseHeaders.
+	// +optional
+	Format string `json:"format,omitempty"`
+
+	// RequestHeaders is the list of request headers captured in access log
+	// entries, available to Format through `RequestHeaders`.
+	// +optional
+	RequestHeaders []string `json:"requestHeaders,omitempty"`
+
+	// ResponseHeaders is the list of response headers captured in access log
+	// entries, available to Format through `ResponseHeaders`.
+	// +optional
+	ResponseHeaders []string `json:"responseHeaders,omitempty"`
+}
+
+// IngressVisibility describes whether the Ingress should be exposed to
+// public gateways or not.
+type IngressVisibility string
+
+const (
+	// IngressVisibilityExternalIP is used to denote that the Ingress
+	// should be exposed via an external IP, for example a LoadBalancer
+	// Service.  This is the default value for IngressVisibility.
+	IngressVisibilityExternalIP IngressVisibility = "ExternalIP"
+	// IngressVisibilityClusterLocal is used to denote that the Ingress
+	// should be only be exposed locally to the cluster.
+	IngressVisibilityClusterLocal IngressVisibility = "ClusterLocal"
+)
+
+// IngressTLS describes the transport layer security associated with an Ingress.
+type IngressTLS struct {
+	// Hosts is a list of hosts included in the TLS certificate. The values in
+	// this list must match the name/s used in the tlsSecret. Defaults to the
+	// wildcard host setting for the loadbalancer controller fulfilling this
+	// Ingress, if left unspecified.
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
+
+	// SecretName is the name of the secret used to terminate SSL traffic.
+	SecretName string `json:"secretName,omitempty"`
+
+	// SecretNamespace is the namespace of the secret used to terminate SSL traffic.
+	SecretNamespace string `json:"secretNamespace,omitempty"`
+}
+
+// IngressRule represents the rules mapping the paths under a specified host to
+// the related backend services. Incoming requests are first evaluated for a host
+// match, then routed to the backend associated with the matching IngressRuleValue.
+type IngressRule struct {
+	// Host is the fully qualified domain name of a network host, as defined
+	// by RFC 3986. Note the following deviations from the "host" part of the
+	// URI as defined in the RFC:
+	// 1. IPs are not allowed. Currently a rule value can only apply to the
+	//	  IP in the Spec of the parent .
+	// 2. The `:` delimiter is not respected because ports are not allowed.
+	//	  Currently the port of an Ingress is implicitly :80 for http and
+	//	  :443 for https.
+	// Both these may change in the future.
+	// If the host is unspecified, the Ingress routes all traffic based on the
+	// specified IngressRuleValue.
+	// If multiple matching Hosts were provided, the first rule will take precedent.
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
+
+	// Visibility signifies whether this rule should `ClusterLocal`. If it's not
+	// specified then it defaults to `ExternalIP`.
+	Visibility IngressVisibility `json:"visibility,omitempty"`
+
+	// AllowedSourceRanges is a list of CIDRs. If it is not empty, only
+	// requests whose source address falls within one of the ranges are
+	// admitted, all others are rejected with a 403.
+	//
+	// The source address is the address of the peer connected to the gateway,
+	// unless the gateway is configured to trust `X-Forwarded-For` from the
+	// load balancer in front of it, in which case it is the client address
+	// reported by that load balancer. `X-Forwarded-For` sent by clients is
+	// never trusted. For `ClusterLocal` rules the source address is the
+	// in-cluster address of the caller, e.g. its Pod IP.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	AllowedSourceRanges []string `json:"allowedSourceRanges,omitempty"`
+
+	// DeniedSourceRanges is a list of CIDRs. Requests whose source address
+	// falls within one of the ranges are rejected with a 403, even if they
+	// are also within AllowedSourceRanges.
+	//
+	// The source address is determined as for AllowedSourceRanges.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	DeniedSourceRanges []string `json:"deniedSourceRanges,omitempty"`
+
+	// ErrorResponses replaces the body, and optionally the status code, of
+	// error responses sent for the hosts of this rule. This covers both
+	// errors returned by the backends and errors generated by the Ingress
+	// itself, e.g. when no backend is available. A status code may appear
+	// in at most one entry.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	ErrorResponses []ErrorResponse `json:"errorResponses,omitempty"`
+
+	// Port is the port on which the hosts of this rule are served, instead
+	// of the standard ports of the Ingress (80 for HTTP, 443 for HTTPS).
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// Protocol is the protocol clients use to connect to Port.  HTTPS
+	// requires the hosts of this rule to be covered by the TLS settings of
+	// the Ingress.  It may only be set along with Port, and defaults to HTTP.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	Protocol ListenerProtocol `json:"protocol,omitempty"`
+
+	// DefaultBackend is the backend receiving requests for the hosts of this
+	// rule whose path matches none of its HTTPIngressPaths.  It takes
+	// precedence over the DefaultBackend of the IngressSpec.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	DefaultBackend *IngressBackend `json:"defaultBackend,omitempty"`
+
+	// HTTP represents a rule to apply against incoming requests. If the
+	// rule is satisfied, the request is routed to the specified backend.
+	HTTP *HTTPIngressRuleValue `json:"http,omitempty"`
+}
+
+// ListenerProtocol is the protocol clients use to connect to the port on
+// which an IngressRule is served.
+type ListenerProtocol string
+
+const (
+	// ListenerProtocolHTTP serves plaintext HTTP.
+	ListenerProtocolHTTP ListenerProtocol = "HTTP"
+
+	// ListenerProtocolHTTPS serves HTTP over TLS.
+	ListenerProtocolHTTPS ListenerProtocol = "HTTPS"
+)
+
+// HTTPIngressRuleValue is a list of http selectors pointing to backends.
+// In the example: http://<host>/<path>?<searchpart> -> backend where
+// where parts of the url correspond to RFC 3986, this resource will be used
+// to match against everything after the last '/' and before the first '?'
+// or '#'.
+type HTTPIngressRuleValue struct {
+	// A collection of paths that map requests to backends.
+	//
+	// Paths are evaluated in order of decreasing Priority, and paths of equal
+	// Priority in the order they are declared.  If there are multiple
+	// matching paths, the first one evaluated takes precedence, regardless
+	// of the length of the match.
+	Paths []HTTPIngressPath `json:"paths"`
+
+	// TODO: Consider adding fields for ingress-type specific global
+	// options usable by a loadbalancer, like http keep-alive.
+}
+
+// HTTPIngressPath associates a path regex with a backend. Incoming URLs matching
+// the path are forwarded to the backend.
+type HTTPIngressPath struct {
+	// Path is an extended POSIX regex as defined by IEEE Std 1003.1,
+	// (i.e this follows the egrep/unix syntax, not the perl syntax)
+	// matched against the path of an incoming request. Currently it can
+	// contain characters disallowed from the conventional "path"
+	// part of a URL as defined by RFC 3986. Paths must begin with
+	// a '/'. If unspecified, the path defaults to a catch all sending
+	// traffic to the backend.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Priority orders the evaluation of the paths of a rule: paths with a
+	// higher Priority are evaluated first.  Paths of equal Priority are
+	// evaluated in the order they are declared.  Defaults to 0.
+	// +optional
+	Priority int `json:"priority,omitempty"`
+
+	// RewriteHost rewrites the incoming request's host header.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	RewriteHost string `json:"rewriteHost,omitempty"`
+
+	// Headers defines header matching rules which is a map from a header name
+	// to HeaderMatch which specify a matching condition.
+	// When a request matched with all the header matching rules,
+	// the request is routed by the corresponding ingress rule.
+	// If it is empty, the headers are not used for matching
+	// +optional
+	Headers map[string]HeaderMatch `json:"headers,omitempty"`
+
+	// Splits defines the referenced service endpoints to which the traffic
+	// will be forwarded to.
+	//
+	// If Splits are specified, RewriteHost must not be.
+	Splits []IngressBackendSplit `json:"splits"`
+
+	// AppendHeaders allow specifying additional HTTP headers to add
+	// before forwarding a request to the destination service.
+	//
+	// NOTE: This differs from K8s Ingress which doesn't allow header appending.
+	// +optional
+	AppendHeaders map[string]string `json:"appendHeaders,omitempty"`
+
+	// Timeout for HTTP requests.
+	//
+	// NOTE: This differs from K8s Ingress which doesn't allow setting timeouts.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// JWT requires requests matching this path to carry a valid JSON Web
+	// Token. Requests without one are rejected with a 401.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	JWT *JWTAuthentication `json:"jwt,omitempty"`
+
+	// ExtAuthz delegates the decision whether to allow requests matching this
+	// path to an external authorization service.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	ExtAuthz *ExtAuthz `json:"extAuthz,omitempty"`
+
+	// Compression configures compression of the responses to requests
+	// matching this path. Responses are only compressed if the client
+	// accepts one of the configured algorithms through `Accept-Encoding`
+	// and the backend did not compress them already.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	Compression *Compression `json:"compression,omitempty"`
+}
+
+// Compression describes how responses are compressed by the Ingress.
+type Compression struct {
+	// Algorithms is the list of compression algorithms offered, in order
+	// of preference. Defaults to `gzip`.
+	// +optional
+	Algorithms []CompressionAlgorithm `json:"algorithms,omitempty"`
+
+	// MinimumSize is the minimum size of a response body in bytes for it
+	// to be compressed.
+	// +optional
+	MinimumSize int64 `json:"minimumSize,omitempty"`
+
+	// ContentTypes is the list of media types, e.g. `text/html`, of the
+	// responses that are compressed. If empty, the Ingress implementation
+	// picks a set of common textual media types.
+	// +optional
+	ContentTypes []string `json:"contentTypes,omitempty"`
+}
+
+// CompressionAlgorithm is an enumeration of the supported compression algorithms.
+type CompressionAlgorithm string
+
+const (
+	// CompressionAlgorithmGzip maps to gzip (RFC 1952).
+	CompressionAlgorithmGzip CompressionAlgorithm = "gzip"
+	// CompressionAlgorithmBrotli maps to Brotli (RFC 7932).
+	CompressionAlgorithmBrotli CompressionAlgorithm = "br"
+)
+
+// JWTAuthentication describes how requests are authenticated with JSON Web Tokens.
+type JWTAuthentication struct {
+	// Issuers is the list of accepted token issuers, matched against the
+	// `iss` claim of the token.
+	Issuers []string `json:"issuers"`
+
+	// Audiences is the list of accepted token audiences, matched against the
+	// `aud` claim of the token. If empty, the audience is not checked.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+
+	// JWKS is the JSON Web Key Set used to verify the token signature.
+	JWKS JWKSSource `json:"jwks"`
+
+	// FromHeader is the name of the header the token is read from. A
+	// `Bearer ` prefix on the header value is stripped.
+	// If neither FromHeader nor FromCookie is set, the token is read from
+	// the `Authorization` header.
+	// +optional
+	FromHeader string `json:"fromHeader,omitempty"`
+
+	// FromCookie is the name of the cookie the token is read from.
+	// +optional
+	FromCookie string `json:"fromCookie,omitempty"`
+
+	// ForwardClaims is a map from a claim name to the name of a header which
+	// is set to the value of that claim before forwarding the request to the
+	// backend.
+	// +optional
+	ForwardClaims map[string]string `json:"forwardClaims,omitempty"`
+}
+
+// JWKSSource describes where a JSON Web Key Set is read from.
+// Exactly one of the fields must be set.
+type JWKSSource struct {
+	// Inline is the JSON Web Key Set document itself.
+	// +optional
+	Inline string `json:"inline,omitempty"`
+
+	// SecretRef references the Secret key holding the JSON Web Key Set document.
+	// +optional
+	SecretRef *SecretKeyReference `json:"secretRef,omitempty"`
+}
+
+// ExtAuthz describes an external authorization service which is consulted
+// before a request is forwarded to its backend. The service receives the
+// request metadata and allows the request by responding with a 2xx status,
+// any other status denies it and is returned to the client.
+type ExtAuthz struct {
+	// Service is the authorization service. Its Protocol selects whether the
+	// check is made over HTTP (the default) or gRPC.
+	Service IngressBackend `json:"service"`
+
+	// RequestHeaders is the list of headers of the incoming request that are
+	// sent to the authorization service.
+	// +optional
+	RequestHeaders []string `json:"requestHeaders,omitempty"`
+
+	// UpstreamHeaders is the list of headers of an allowing authorization
+	// response that are added to the request before forwarding it to the
+	// backend.
+	// +optional
+	UpstreamHeaders []string `json:"upstreamHeaders,omitempty"`
+
+	// Timeout for the authorization check.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// FailureMode decides what happens to the request if the authorization
+	// service cannot be reached or times out. Defaults to `Deny`.
+	// +optional
+	FailureMode ExtAuthzFailureMode `json:"failureMode,omitempty"`
+}
+
+// ExtAuthzFailureMode describes how requests are handled when the external
+// authorization service fails.
+type ExtAuthzFailureMode string
+
+const (
+	// ExtAuthzFailureModeDeny rejects the request when the authorization
+	// service fails. This is the default value for ExtAuthzFailureMode.
+	ExtAuthzFailureModeDeny ExtAuthzFailureMode = "Deny"
+	// ExtAuthzFailureModeAllow forwards the request to the backend when the
+	// authorization service fails.
+	ExtAuthzFailureModeAllow ExtAuthzFailureMode = "Allow"
+)
+
+// ErrorResponse describes the response sent instead of an error response
+// with one of the given status codes.
+type ErrorResponse struct {
+	// StatusCodes are the status codes of the error responses to replace.
+	// They must be in the 4xx or 5xx range.
+	StatusCodes []int `json:"statusCodes"`
+
+	// Body references the ConfigMap key whose value is sent as the body of
+	// the response. Changes to the ConfigMap's data do not change the Ingress
+	// and so are not reflected in its status.
+	Body ConfigMapKeyReference `json:"body"`
+
+	// ContentType is the media type of Body. Defaults to text/html.
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+
+	// StatusCode is the status code sent instead of the original one. When
+	// unset, the original status code is kept.
+	// +optional
+	StatusCode int `json:"statusCode,omitempty"`
+}
+
+// ConfigMapKeyReference references a key of a ConfigMap.
+type ConfigMapKeyReference struct {
+	// ConfigMapName is the name of the referenced ConfigMap.
+	ConfigMapName string `json:"configMapName"`
+
+	// ConfigMapNamespace is the namespace of the referenced ConfigMap.
+	ConfigMapNamespace string `json:"configMapNamespace"`
+
+	// Key is the key within the ConfigMap's data.
+	Key string `json:"key"`
+}
+
+// SecretKeyReference references a key of a Secret.
+type SecretKeyReference struct {
+	// SecretName is the name of the referenced Secret.
+	SecretName string `json:"secretName"`
+
+	// SecretNamespace is the namespace of the referenced Secret.
+	SecretNamespace string `json:"secretNamespace"`
+
+	// Key is the key within the Secret's data.
+	Key string `json:"key"`
+}
+
+// IngressBackendSplit describes all endpoints for a given service and port.
+type IngressBackendSplit struct {
+	// Specifies the backend receiving the traffic split.
+	IngressBackend `json:",inline"`
+
+	// Specifies the split percentage, a number between 0 and 100.  If
+	// only one split is specified, we default to 100.
+	//
+	// NOTE: This differs from K8s Ingress to allow percentage split.
+	Percent int `json:"percent,omitempty"`
+
+	// AppendHeaders allow specifying additional HTTP headers to add
+	// before forwarding a request to the destination service.
+	//
+	// NOTE: This differs from K8s Ingress which doesn't allow header appending.
+	// +optional
+	AppendHeaders map[string]string `json:"appendHeaders,omitempty"`
+
+	// Locality configures how the requests for this split are spread across
+	// the zones of its endpoints.  When unset, requests are spread evenly
+	// across the healthy endpoints, regardless of their zone.
+	//
+	// This field is currently experimental and not supported by all Ingress
+	// implementations.
+	// +optional
+	Locality *LocalityPreferences `json:"locality,omitempty"`
+}
+
+// LocalityPreferences describes how requests are spread across the zones of
+// the endpoints of a backend, depending on the zone of the Ingress instance
+// receiving them.  Zones are the values of the `topology.kubernetes.io/zone`
+// label of the Nodes.  Distribution may not be combined with the other
+// preferences.
+type LocalityPreferences struct {
+	// PreferSameZone sends requests to the endpoints in the zone of the
+	// Ingress instance receiving them, as long as that zone has healthy
+	// endpoints.
+	// +optional
+	PreferSameZone bool `json:"preferSameZone,omitempty"`
+
+	// FailoverZones is the order in which other zones are tried when the
+	// zone of the Ingress instance has no healthy endpoints.  If none of them
+	// have healthy endpoints either, requests are spread evenly across the
+	// healthy endpoints of the remaining zones.  Requires PreferSameZone.
+	// +optional
+	FailoverZones []string `json:"failoverZones,omitempty"`
+
+	// Distribution overrides, per zone of the Ingress instance, the share of
+	// requests sent to each zone.  Zones without healthy endpoints are
+	// skipped, and their share spread over the other listed zones.
+	// +optional
+	Distribution []LocalityDistribution `json:"distribution,omitempty"`
+}
+
+// LocalityDistribution describes the share of requests received by the
+// Ingress instances of a zone that is sent to each zone.
+type LocalityDistribution struct {
+	// From is the zone of the Ingress instances receiving the requests.
+	From string `json:"from"`
+
+	// To maps zones to the percentage of the requests they receive.  The
+	// percentages must total to 100.
+	To map[string]int `json:"to"`
+}
+
+// IngressBackend describes all endpoints for a given service and port.
+type IngressBackend struct {
+	// Specifies the namespace of the referenced service.
+	//
+	// NOTE: This differs from K8s Ingress to allow routing to different namespaces.
+	ServiceNamespace string `json:"serviceNamespace"`
+
+	// Specifies the name of the referenced service.
+	ServiceName string `json:"serviceName"`
+
+	// Specifies the port of the referenced service.
+	ServicePort intstr.IntOrString `json:"servicePort"`
+
+	// Protocol is the application-layer protocol spoken by the referenced
+	// service port. If unspecified, implementations infer the protocol from
+	// the name of the service port, e.g. `http2` for h2c.
+	//
+	// NOTE: This differs from K8s Ingress which doesn't allow setting the protocol.
+	// +optional
+	Protocol networking.ProtocolType `json:"protocol,omitempty"`
+}
+
+// IngressStatus describe the current state of the Ingress.
+type IngressStatus struct {
+	duckv1.Status `json:",inline"`
+
+	// PublicLoadBalancer contains the current status of the load-balancer.
+	// +optional
+	PublicLoadBalancer *LoadBalancerStatus `json:"publicLoadBalancer,omitempty"`
+
+	// PrivateLoadBalancer contains the current status of the load-balancer.
+	// +optional
+	PrivateLoadBalancer *LoadBalancerStatus `json:"privateLoadBalancer,omitempty"`
+
+	// Rules contains the status of each of the hosts of the rules of the
+	// Ingress, sorted by host.
+	// +optional
+	Rules []IngressRuleStatus `json:"rules,omitempty"`
+}
+
+// IngressRuleStatus describes the status of a host of a rule of an Ingress.
+type IngressRuleStatus struct {
+	// Host is the host this status is about.
+	Host string `json:"host"`
+
+	// Ready is True once the host is served according to the latest spec of
+	// the Ingress, and Unknown until then.
+	Ready corev1.ConditionStatus `json:"ready"`
+
+	// URL is the URL at which the host is served.
+	// +optional
+	URL *apis.URL `json:"url,omitempty"`
+
+	// LoadBalancer is the load balancer serving the host.
+	// +optional
+	LoadBalancer *LoadBalancerIngressStatus `json:"loadBalancer,omitempty"`
+
+	// LastProbeError is the error of the last failed probe of the host.  It is
+	// cleared once the host is ready.
+	// +optional
+	LastProbeError string `json:"lastProbeError,omitempty"`
+}
+
+// LoadBalancerStatus represents the status of a load-balancer.
+type LoadBalancerStatus struct {
+	// Ingress is a list containing ingress points for the load-balancer.
+	// Traffic intended for the service should be sent to these ingress points.
+	// +optional
+	Ingress []LoadBalancerIngressStatus `json:"ingress,omitempty"`
+}
+
+// LoadBalancerIngressStatus represents the status of a load-balancer ingress point:
+// traffic intended for the service should be sent to an ingress point.
+type LoadBalancerIngressStatus struct {
+	// IP is set for load-balancer ingress points that are IP based
+	// (typically GCE or OpenStack load-balancers)
+	// +optional
+	IP string `json:"ip,omitempty"`
+
+	// Domain is set for load-balancer ingress points that are DNS based
+	// (typically AWS load-balancers)
+	// +optional
+	Domain string `json:"domain,omitempty"`
+
+	// DomainInternal is set if there is a cluster-local DNS name to access the Ingress.
+	//
+	// NOTE: This differs from K8s Ingress, since we also desire to have a cluster-local
+	//       DNS name to allow routing in case of not having a mesh.
+	//
+	// +optional
+	DomainInternal string `json:"domainInternal,omitempty"`
+
+	// MeshOnly is set if the Ingress is only load-balanced through a Service mesh.
+	// +optional
+	MeshOnly bool `json:"meshOnly,omitempty"`
+}
+
+// ConditionType represents a Ingress condition value
+const (
+	// IngressConditionReady is set when the Ingress networking setting is
+	// configured and it has a load balancer address.
+	IngressConditionReady = apis.ConditionReady
+
+	// IngressConditionNetworkConfigured is set when the Ingress's underlying
+	// network programming has been configured.  This doesn't include conditions of the
+	// backends, so even if this should remain true when network is configured and backends
+	// are not ready.
+	IngressConditionNetworkConfigured apis.ConditionType = "NetworkConfigured"
+
+	// IngressConditionLoadBalancerReady is set when the Ingress has a ready LoadBalancer.
+	IngressConditionLoadBalancerReady apis.ConditionType = "LoadBalancerReady"
+
+	// IngressConditionFeatureSupported is set when the Ingress implementation
+	// supports all the fields set on the Ingress.  It is false, with reason
+	// IngressReasonFeatureNotSupported, when the implementation would have to
+	// ignore some of them.
+	IngressConditionFeatureSupported apis.ConditionType = "FeatureSupported"
+)
+
+// IngressReasonFeatureNotSupported is the reason of the
+// IngressConditionFeatureSupported condition when the Ingress implementation
+// does not support some of the fields set on the Ingress.
+const IngressReasonFeatureNotSupported = "FeatureNotSupported"
+
+// GetStatus retrieves the status of the Ingress. Implements the KRShaped interface.
+func (t *Ingress) GetStatus() *duckv1.Status {
+	return &t.Status.Status
+}
+
+// HeaderMatch represents a matching value of Headers in HTTPIngressPath.
+// Currently, only the exact matching is supported.
+type HeaderMatch struct {
+	Exact string `json:"exact"`
+}
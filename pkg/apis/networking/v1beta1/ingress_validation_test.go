@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/pkg/apis"
+)
+
+func TestIngressValidation(t *testing.T) {
+	ingress := func(class string, percent int) *Ingress {
+		return &Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "test-ingress",
+				Annotations: map[string]string{networking.IngressClassAnnotationKey: class},
+			},
+			Spec: IngressSpec{
+				Rules: []IngressRule{{
+					Hosts:      []string{"example.com"},
+					Visibility: IngressVisibilityExternalIP,
+					HTTP: &HTTPIngressRuleValue{
+						Paths: []HTTPIngressPath{{
+							Splits: []IngressBackendSplit{{
+								IngressBackend: IngressBackend{
+									ServiceName:      "revision-000",
+									ServiceNamespace: "default",
+									ServicePort:      intstr.FromInt(8080),
+								},
+								Percent: percent,
+							}},
+						}},
+					},
+				}},
+			},
+		}
+	}
+	installed := v1alpha1.WithInstalledIngressClasses(context.Background(),
+		sets.NewString("installed.ingress.networking.knative.dev"))
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		ing  *Ingress
+		want *apis.FieldError
+	}{{
+		name: "valid",
+		ctx:  installed,
+		ing:  ingress("installed.ingress.networking.knative.dev", 100),
+	}, {
+		name: "invalid spec",
+		ctx:  installed,
+		ing:  ingress("installed.ingress.networking.knative.dev", 90),
+		want: &apis.FieldError{
+			Message: "traffic split percentage must total to 100, but was 90",
+			Paths:   []string{"spec.rules[0].http.paths[0].splits"},
+		},
+	}, {
+		name: "unknown class",
+		ctx:  installed,
+		ing:  ingress("unknown.ingress.networking.knative.dev", 100),
+		want: &apis.FieldError{
+			Message: `ingress class "unknown.ingress.networking.knative.dev" is not installed`,
+			Paths:   []string{"metadata.annotations[networking.knative.dev/ingress.class]"},
+		},
+	}, {
+		name: "update keeping unknown class",
+		ctx:  apis.WithinUpdate(installed, ingress("unknown.ingress.networking.knative.dev", 100)),
+		ing:  ingress("unknown.ingress.networking.knative.dev", 100),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.ing.Validate(test.ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
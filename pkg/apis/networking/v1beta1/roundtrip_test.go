@@ -0,0 +1,47 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1beta1
+
+import (
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// fuzzRounds is the number of random objects each round-trip test converts.
+	fuzzRounds = 200
+	// fuzzSeed makes the random objects, and thus the failures, reproducible.
+	fuzzSeed = 20200917
+)
+
+// newFuzzer returns a fuzzer filling objects with random values, except for
+// those types whose random values can't be compared.
+func newFuzzer(t *testing.T) *fuzz.Fuzzer {
+	t.Logf("Fuzzing with seed %d", fuzzSeed)
+	return fuzz.NewWithSeed(fuzzSeed).NilChance(.2).NumElements(0, 3).Funcs(
+		// The TypeMeta is that of the version converted to, not copied.
+		func(*metav1.TypeMeta, fuzz.Continue) {},
+		// url.Userinfo has no exported fields, and can't be compared.
+		func(u *apis.URL, c fuzz.Continue) {
+			u.Scheme = "http"
+			u.Host = c.RandString()
+			u.Path = "/" + c.RandString()
+		},
+	)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/pkg/apis"
+)
+
+// ConvertTo implements apis.Convertible.
+// Converts source (from v1beta1.ServerlessService) into v1alpha1.ServerlessService.
+func (source *ServerlessService) ConvertTo(ctx context.Context, to apis.Convertible) error {
+	switch sink := to.(type) {
+	case *v1alpha1.ServerlessService:
+		sink.ObjectMeta = source.ObjectMeta
+		sink.Spec = v1alpha1.ServerlessServiceSpec{
+			Mode:          v1alpha1.ServerlessServiceOperationMode(source.Spec.Mode),
+			ObjectRef:     source.Spec.ObjectRef,
+			ProtocolType:  source.Spec.ProtocolType,
+			NumActivators: source.Spec.NumActivators,
+		}
+		sink.Status = v1alpha1.ServerlessServiceStatus(source.Status)
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", sink)
+	}
+}
+
+// ConvertFrom implements apis.Convertible.
+// Converts obj from v1alpha1.ServerlessService into v1beta1.ServerlessService.
+func (sink *ServerlessService) ConvertFrom(ctx context.Context, from apis.Convertible) error {
+	switch source := from.(type) {
+	case *v1alpha1.ServerlessService:
+		sink.ObjectMeta = source.ObjectMeta
+		sink.Spec = ServerlessServiceSpec{
+			Mode:          ServerlessServiceOperationMode(source.Spec.Mode),
+			ObjectRef:     source.Spec.ObjectRef,
+			ProtocolType:  source.Spec.ProtocolType,
+			NumActivators: source.Spec.NumActivators,
+		}
+		sink.Status = ServerlessServiceStatus(source.Status)
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", source)
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+)
+
+func TestServerlessServiceConversionBadType(t *testing.T) {
+	good, bad := &ServerlessService{}, &v1alpha1.Ingress{}
+
+	if err := good.ConvertTo(context.Background(), bad); err == nil {
+		t.Errorf("ConvertTo() = %#v, wanted error", bad)
+	}
+
+	if err := good.ConvertFrom(context.Background(), bad); err == nil {
+		t.Errorf("ConvertFrom() = %#v, wanted error", good)
+	}
+}
+
+func TestServerlessServiceConversionRoundTrip(t *testing.T) {
+	f := newFuzzer(t)
+	for i := 0; i < fuzzRounds; i++ {
+		want := &ServerlessService{}
+		f.Fuzz(want)
+
+		storage := &v1alpha1.ServerlessService{}
+		if err := want.ConvertTo(context.Background(), storage); err != nil {
+			t.Fatal("ConvertTo() =", err)
+		}
+		got := &ServerlessService{}
+		if err := got.ConvertFrom(context.Background(), storage); err != nil {
+			t.Fatal("ConvertFrom() =", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatal("Round trip (-want, +got) =", diff)
+		}
+	}
+}
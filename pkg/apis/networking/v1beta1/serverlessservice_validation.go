@@ -0,0 +1,33 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1beta1
+
+import (
+	"context"
+
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/pkg/apis"
+)
+
+// Validate inspects and validates ServerlessService object, using the rules of
+// v1alpha1.
+func (ss *ServerlessService) Validate(ctx context.Context) *apis.FieldError {
+	storage := &v1alpha1.ServerlessService{}
+	if err := ss.ConvertTo(ctx, storage); err != nil {
+		return apis.ErrGeneric(err.Error())
+	}
+	return storage.Validate(withStorageBaseline(ctx, &v1alpha1.ServerlessService{}))
+}
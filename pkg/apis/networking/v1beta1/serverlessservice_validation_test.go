@@ -0,0 +1,70 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/pkg/apis"
+)
+
+func TestServerlessServiceValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		sks  *ServerlessService
+		want *apis.FieldError
+	}{{
+		name: "valid",
+		sks: &ServerlessService{
+			Spec: ServerlessServiceSpec{
+				Mode: SKSOperationModeProxy,
+				ObjectRef: corev1.ObjectReference{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       "foo",
+				},
+				ProtocolType: networking.ProtocolHTTP1,
+			},
+		},
+		want: nil,
+	}, {
+		name: "no mode",
+		sks: &ServerlessService{
+			Spec: ServerlessServiceSpec{
+				ObjectRef: corev1.ObjectReference{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       "foo",
+				},
+				ProtocolType: networking.ProtocolHTTP1,
+			},
+		},
+		want: apis.ErrMissingField("spec.mode"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.sks.Validate(context.Background())
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
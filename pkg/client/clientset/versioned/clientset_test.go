@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package versioned
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"knative.dev/networking/pkg/apis/networking/v1beta1"
+)
+
+func TestV1beta1Client(t *testing.T) {
+	const basePath = "/apis/networking.internal.knative.dev/v1beta1/namespaces/default/ingresses"
+	typeMeta := metav1.TypeMeta{
+		APIVersion: v1beta1.SchemeGroupVersion.String(),
+		Kind:       "Ingress",
+	}
+	ing := v1beta1.Ingress{
+		TypeMeta: typeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "foo",
+		},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp interface{}
+		switch r.URL.Path {
+		case basePath + "/foo":
+			resp = ing
+		case basePath:
+			resp = v1beta1.IngressList{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: v1beta1.SchemeGroupVersion.String(),
+					Kind:       "IngressList",
+				},
+				Items: []v1beta1.Ingress{ing},
+			}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ts.Close()
+
+	client, err := NewForConfig(&rest.Config{Host: ts.URL})
+	if err != nil {
+		t.Fatal("NewForConfig() =", err)
+	}
+	ingresses := client.NetworkingV1beta1().Ingresses("default")
+
+	got, err := ingresses.Get("foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("Get() =", err)
+	}
+	if got.Name != "foo" {
+		t.Errorf("Get().Name = %q, want: %q", got.Name, "foo")
+	}
+
+	list, err := ingresses.List(metav1.ListOptions{LabelSelector: "foo=bar"})
+	if err != nil {
+		t.Fatal("List() =", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Name != "foo" {
+		t.Errorf("List().Items = %v, want the foo Ingress", list.Items)
+	}
+}
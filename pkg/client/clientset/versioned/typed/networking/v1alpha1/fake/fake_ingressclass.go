@@ -0,0 +1,131 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
+)
+
+// FakeIngressClasses implements IngressClassInterface
+type FakeIngressClasses struct {
+	Fake *FakeNetworkingV1alpha1
+}
+
+var ingressclassesResource = schema.GroupVersionResource{Group: "networking.internal.knative.dev", Version: "v1alpha1", Resource: "ingressclasses"}
+
+var ingressclassesKind = schema.GroupVersionKind{Group: "networking.internal.knative.dev", Version: "v1alpha1", Kind: "IngressClass"}
+
+// Get takes name of the ingressClass, and returns the corresponding ingressClass object, and an error if there is any.
+func (c *FakeIngressClasses) Get(name string, options v1.GetOptions) (result *v1alpha1.IngressClass, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(ingressclassesResource, name), &v1alpha1.IngressClass{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.IngressClass), err
+}
+
+// List takes label and field selectors, and returns the list of IngressClasses that match those selectors.
+func (c *FakeIngressClasses) List(opts v1.ListOptions) (result *v1alpha1.IngressClassList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(ingressclassesResource, ingressclassesKind, opts), &v1alpha1.IngressClassList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.IngressClassList{ListMeta: obj.(*v1alpha1.IngressClassList).ListMeta}
+	for _, item := range obj.(*v1alpha1.IngressClassList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested ingressClasses.
+func (c *FakeIngressClasses) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(ingressclassesResource, opts))
+}
+
+// Create takes the representation of a ingressClass and creates it.  Returns the server's representation of the ingressClass, and an error, if there is any.
+func (c *FakeIngressClasses) Create(ingressClass *v1alpha1.IngressClass) (result *v1alpha1.IngressClass, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(ingressclassesResource, ingressClass), &v1alpha1.IngressClass{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.IngressClass), err
+}
+
+// Update takes the representation of a ingressClass and updates it. Returns the server's representation of the ingressClass, and an error, if there is any.
+func (c *FakeIngressClasses) Update(ingressClass *v1alpha1.IngressClass) (result *v1alpha1.IngressClass, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(ingressclassesResource, ingressClass), &v1alpha1.IngressClass{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.IngressClass), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeIngressClasses) UpdateStatus(ingressClass *v1alpha1.IngressClass) (*v1alpha1.IngressClass, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(ingressclassesResource, "status", ingressClass), &v1alpha1.IngressClass{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.IngressClass), err
+}
+
+// Delete takes name of the ingressClass and deletes it. Returns an error if one occurs.
+func (c *FakeIngressClasses) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(ingressclassesResource, name), &v1alpha1.IngressClass{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeIngressClasses) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(ingressclassesResource, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.IngressClassList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched ingressClass.
+func (c *FakeIngressClasses) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.IngressClass, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(ingressclassesResource, name, pt, data, subresources...), &v1alpha1.IngressClass{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.IngressClass), err
+}
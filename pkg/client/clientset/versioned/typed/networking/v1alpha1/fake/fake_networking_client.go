@@ -40,6 +40,10 @@ func (c *FakeNetworkingV1alpha1) Ingresses(namespace string) v1alpha1.IngressInt
 	return &FakeIngresses{c, namespace}
 }
 
+func (c *FakeNetworkingV1alpha1) IngressClasses() v1alpha1.IngressClassInterface {
+	return &FakeIngressClasses{c}
+}
+
 func (c *FakeNetworkingV1alpha1) Realms() v1alpha1.RealmInterface {
 	return &FakeRealms{c}
 }
@@ -24,6 +24,8 @@ type DomainExpansion interface{}
 
 type IngressExpansion interface{}
 
+type IngressClassExpansion interface{}
+
 type RealmExpansion interface{}
 
 type ServerlessServiceExpansion interface{}
@@ -29,6 +29,7 @@ type NetworkingV1alpha1Interface interface {
 	CertificatesGetter
 	DomainsGetter
 	IngressesGetter
+	IngressClassesGetter
 	RealmsGetter
 	ServerlessServicesGetter
 }
@@ -50,6 +51,10 @@ func (c *NetworkingV1alpha1Client) Ingresses(namespace string) IngressInterface
 	return newIngresses(c, namespace)
 }
 
+func (c *NetworkingV1alpha1Client) IngressClasses() IngressClassInterface {
+	return newIngressClasses(c)
+}
+
 func (c *NetworkingV1alpha1Client) Realms() RealmInterface {
 	return newRealms(c)
 }
@@ -24,6 +24,7 @@ import (
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 	v1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
+	v1beta1 "knative.dev/networking/pkg/apis/networking/v1beta1"
 )
 
 // GenericInformer is type of SharedIndexInformer which will locate and delegate to other
@@ -59,11 +60,21 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Networking().V1alpha1().Domains().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("ingresses"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Networking().V1alpha1().Ingresses().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("ingressclasses"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Networking().V1alpha1().IngressClasses().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("realms"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Networking().V1alpha1().Realms().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("serverlessservices"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Networking().V1alpha1().ServerlessServices().Informer()}, nil
 
+		// Group=networking.internal.knative.dev, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("certificates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Networking().V1beta1().Certificates().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("ingresses"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Networking().V1beta1().Ingresses().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("serverlessservices"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Networking().V1beta1().ServerlessServices().Informer()}, nil
+
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
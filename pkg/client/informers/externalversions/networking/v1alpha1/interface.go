@@ -30,6 +30,8 @@ type Interface interface {
 	Domains() DomainInformer
 	// Ingresses returns a IngressInformer.
 	Ingresses() IngressInformer
+	// IngressClasses returns a IngressClassInformer.
+	IngressClasses() IngressClassInformer
 	// Realms returns a RealmInformer.
 	Realms() RealmInformer
 	// ServerlessServices returns a ServerlessServiceInformer.
@@ -62,6 +64,11 @@ func (v *version) Ingresses() IngressInformer {
 	return &ingressInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// IngressClasses returns a IngressClassInformer.
+func (v *version) IngressClasses() IngressClassInformer {
+	return &ingressClassInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Realms returns a RealmInformer.
 func (v *version) Realms() RealmInformer {
 	return &realmInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
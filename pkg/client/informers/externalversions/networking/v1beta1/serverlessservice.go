@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	time "time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+	networkingv1beta1 "knative.dev/networking/pkg/apis/networking/v1beta1"
+	versioned "knative.dev/networking/pkg/client/clientset/versioned"
+	internalinterfaces "knative.dev/networking/pkg/client/informers/externalversions/internalinterfaces"
+	v1beta1 "knative.dev/networking/pkg/client/listers/networking/v1beta1"
+)
+
+// ServerlessServiceInformer provides access to a shared informer and lister for
+// ServerlessServices.
+type ServerlessServiceInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1beta1.ServerlessServiceLister
+}
+
+type serverlessServiceInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewServerlessServiceInformer constructs a new informer for ServerlessService type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewServerlessServiceInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredServerlessServiceInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredServerlessServiceInformer constructs a new informer for ServerlessService type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredServerlessServiceInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.NetworkingV1beta1().ServerlessServices(namespace).List(options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.NetworkingV1beta1().ServerlessServices(namespace).Watch(options)
+			},
+		},
+		&networkingv1beta1.ServerlessService{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *serverlessServiceInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredServerlessServiceInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *serverlessServiceInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&networkingv1beta1.ServerlessService{}, f.defaultInformer)
+}
+
+func (f *serverlessServiceInformer) Lister() v1beta1.ServerlessServiceLister {
+	return v1beta1.NewServerlessServiceLister(f.Informer().GetIndexer())
+}
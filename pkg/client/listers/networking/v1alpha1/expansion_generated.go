@@ -38,6 +38,10 @@ type IngressListerExpansion interface{}
 // IngressNamespaceLister.
 type IngressNamespaceListerExpansion interface{}
 
+// IngressClassListerExpansion allows custom methods to be added to
+// IngressClassLister.
+type IngressClassListerExpansion interface{}
+
 // RealmListerExpansion allows custom methods to be added to
 // RealmLister.
 type RealmListerExpansion interface{}
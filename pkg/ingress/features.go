@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	listers "knative.dev/networking/pkg/client/listers/networking/v1alpha1"
+)
+
+// The optional features of the Ingress API, which Ingress implementations may
+// not support.  Implementations pass the set of those they support to
+// UnsupportedFeatures, and publish it as the capabilities of their
+// IngressClass.
+const (
+	// FeatureAccessLog is IngressSpec.AccessLog.
+	FeatureAccessLog = "AccessLog"
+	// FeatureBackendProtocol is IngressBackend.Protocol.
+	FeatureBackendProtocol = "BackendProtocol"
+	// FeatureCompression is HTTPIngressPath.Compression.
+	FeatureCompression = "Compression"
+	// FeatureDefaultBackend is IngressSpec.DefaultBackend and IngressRule.DefaultBackend.
+	FeatureDefaultBackend = "DefaultBackend"
+	// FeatureErrorResponses is IngressRule.ErrorResponses.
+	FeatureErrorResponses = "ErrorResponses"
+	// FeatureExtAuthz is HTTPIngressPath.ExtAuthz.
+	FeatureExtAuthz = "ExtAuthz"
+	// FeatureHeaderMatch is HTTPIngressPath.Headers.
+	FeatureHeaderMatch = "HeaderMatch"
+	// FeatureJWT is HTTPIngressPath.JWT.
+	FeatureJWT = "JWT"
+	// FeatureListenerPort is IngressRule.Port and IngressRule.Protocol.
+	FeatureListenerPort = "ListenerPort"
+	// FeatureLocality is IngressBackendSplit.Locality.
+	FeatureLocality = "Locality"
+	// FeatureProbeHeaders is the handling of the network.ProbeHeaderName header
+	// by the Ingress.
+	FeatureProbeHeaders = "ProbeHeaders"
+	// FeaturePathPriority is HTTPIngressPath.Priority.
+	FeaturePathPriority = "PathPriority"
+	// FeatureRewriteHost is HTTPIngressPath.RewriteHost.
+	FeatureRewriteHost = "RewriteHost"
+	// FeatureSourceRanges is IngressRule.AllowedSourceRanges and IngressRule.DeniedSourceRanges.
+	FeatureSourceRanges = "SourceRanges"
+	// FeatureTagHeaders is the routing on the network.TagHeaderName header.
+	FeatureTagHeaders = "TagHeaders"
+	// FeatureTimeout is HTTPIngressPath.Timeout.
+	FeatureTimeout = "Timeout"
+)
+
+// UnsupportedFeatures returns the paths of the fields set in the given spec
+// that belong to features missing from supported, in the order they appear in
+// the spec.  The result can be passed to IngressStatus.MarkUnsupportedFeature.
+func UnsupportedFeatures(spec *v1alpha1.IngressSpec, supported sets.String) []string {
+	var fields []string
+	check := func(feature string, set bool, path string, args ...interface{}) {
+		if set && !supported.Has(feature) {
+			fields = append(fields, fmt.Sprintf(path, args...))
+		}
+	}
+
+	for i, rule := range spec.Rules {
+		check(FeatureSourceRanges, len(rule.AllowedSourceRanges) > 0, "spec.rules[%d].allowedSourceRanges", i)
+		check(FeatureSourceRanges, len(rule.DeniedSourceRanges) > 0, "spec.rules[%d].deniedSourceRanges", i)
+		check(FeatureErrorResponses, len(rule.ErrorResponses) > 0, "spec.rules[%d].errorResponses", i)
+		check(FeatureListenerPort, rule.Port != 0, "spec.rules[%d].port", i)
+		check(FeatureListenerPort, rule.Protocol != "", "spec.rules[%d].protocol", i)
+		check(FeatureDefaultBackend, rule.DefaultBackend != nil, "spec.rules[%d].defaultBackend", i)
+		if rule.DefaultBackend != nil {
+			check(FeatureBackendProtocol, rule.DefaultBackend.Protocol != "", "spec.rules[%d].defaultBackend.protocol", i)
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for j, path := range rule.HTTP.Paths {
+			check(FeaturePathPriority, path.Priority != 0, "spec.rules[%d].http.paths[%d].priority", i, j)
+			check(FeatureRewriteHost, path.RewriteHost != "", "spec.rules[%d].http.paths[%d].rewriteHost", i, j)
+			check(FeatureHeaderMatch, len(path.Headers) > 0, "spec.rules[%d].http.paths[%d].headers", i, j)
+			check(FeatureTimeout, path.Timeout != nil, "spec.rules[%d].http.paths[%d].timeout", i, j)
+			check(FeatureJWT, path.JWT != nil, "spec.rules[%d].http.paths[%d].jwt", i, j)
+			check(FeatureExtAuthz, path.ExtAuthz != nil, "spec.rules[%d].http.paths[%d].extAuthz", i, j)
+			if path.ExtAuthz != nil {
+				check(FeatureBackendProtocol, path.ExtAuthz.Service.Protocol != "", "spec.rules[%d].http.paths[%d].extAuthz.service.protocol", i, j)
+			}
+			check(FeatureCompression, path.Compression != nil, "spec.rules[%d].http.paths[%d].compression", i, j)
+			for k, split := range path.Splits {
+				check(FeatureBackendProtocol, split.Protocol != "", "spec.rules[%d].http.paths[%d].splits[%d].protocol", i, j, k)
+				check(FeatureLocality, split.Locality != nil, "spec.rules[%d].http.paths[%d].splits[%d].locality", i, j, k)
+			}
+		}
+	}
+	check(FeatureDefaultBackend, spec.DefaultBackend != nil, "spec.defaultBackend")
+	if spec.DefaultBackend != nil {
+		check(FeatureBackendProtocol, spec.DefaultBackend.Protocol != "", "spec.defaultBackend.protocol")
+	}
+	check(FeatureAccessLog, spec.AccessLog != nil, "spec.accessLog")
+	return fields
+}
+
+// WithInstalledIngressClasses returns a context in which Ingress validation
+// rejects the ingress classes that have no IngressClass in the lister.  When
+// the IngressClasses can't be listed the context is returned unchanged, so that
+// the annotation is not checked.
+//
+// This repository runs no webhook, so the check only runs where the validation
+// webhook of the consumer, e.g. Knative Serving's, decorates its context with
+// it:
+//
+//	validation.NewAdmissionController(ctx, name, path, types,
+//		func(ctx context.Context) context.Context {
+//			return ingress.WithInstalledIngressClasses(ctx, ingressClassLister)
+//		}, true)
+func WithInstalledIngressClasses(ctx context.Context, lister listers.IngressClassLister) context.Context {
+	classes, err := lister.List(labels.Everything())
+	if err != nil {
+		return ctx
+	}
+	names := make(sets.String, len(classes))
+	for _, class := range classes {
+		names.Insert(class.Name)
+	}
+	return v1alpha1.WithInstalledIngressClasses(ctx, names)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	listers "knative.dev/networking/pkg/client/listers/networking/v1alpha1"
+)
+
+func TestUnsupportedFeatures(t *testing.T) {
+	spec := &v1alpha1.IngressSpec{
+		Rules: []v1alpha1.IngressRule{{
+			Hosts: []string{"example.com"},
+			HTTP: &v1alpha1.HTTPIngressRuleValue{
+				Paths: []v1alpha1.HTTPIngressPath{{
+					Splits: []v1alpha1.IngressBackendSplit{{
+						IngressBackend: v1alpha1.IngressBackend{
+							ServiceName: "blah",
+						},
+					}},
+					ExtAuthz: &v1alpha1.ExtAuthz{
+						Service: v1alpha1.IngressBackend{
+							ServiceName: "authz",
+							Protocol:    networking.ProtocolGRPC,
+						},
+					},
+				}},
+			},
+		}, {
+			Hosts:              []string{"legacy.example.com"},
+			Port:               9000,
+			DeniedSourceRanges: []string{"10.0.0.0/8"},
+			HTTP: &v1alpha1.HTTPIngressRuleValue{
+				Paths: []v1alpha1.HTTPIngressPath{{
+					Path: "/foo",
+					Splits: []v1alpha1.IngressBackendSplit{{
+						IngressBackend: v1alpha1.IngressBackend{
+							ServiceName: "blah",
+						},
+					}},
+				}, {
+					RewriteHost: "example.com",
+					Timeout:     &metav1.Duration{Duration: time.Second},
+					Splits: []v1alpha1.IngressBackendSplit{{
+						IngressBackend: v1alpha1.IngressBackend{
+							ServiceName: "blah",
+							Protocol:    networking.ProtocolH2C,
+						},
+					}},
+				}},
+			},
+		}},
+		DefaultBackend: &v1alpha1.IngressBackend{
+			ServiceName: "fallback",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		supported sets.String
+		want      []string
+	}{{
+		name: "nothing supported",
+		want: []string{
+			"spec.rules[0].http.paths[0].extAuthz",
+			"spec.rules[0].http.paths[0].extAuthz.service.protocol",
+			"spec.rules[1].deniedSourceRanges",
+			"spec.rules[1].port",
+			"spec.rules[1].http.paths[1].rewriteHost",
+			"spec.rules[1].http.paths[1].timeout",
+			"spec.rules[1].http.paths[1].splits[0].protocol",
+			"spec.defaultBackend",
+		},
+	}, {
+		name:      "some supported",
+		supported: sets.NewString(FeatureTimeout, FeatureDefaultBackend, FeatureSourceRanges, FeatureJWT, FeatureExtAuthz),
+		want: []string{
+			"spec.rules[0].http.paths[0].extAuthz.service.protocol",
+			"spec.rules[1].port",
+			"spec.rules[1].http.paths[1].rewriteHost",
+			"spec.rules[1].http.paths[1].splits[0].protocol",
+		},
+	}, {
+		name: "all supported",
+		supported: sets.NewString(FeatureBackendProtocol, FeatureDefaultBackend, FeatureExtAuthz,
+			FeatureListenerPort, FeatureRewriteHost, FeatureSourceRanges, FeatureTimeout),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := UnsupportedFeatures(spec, test.supported)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("UnsupportedFeatures (-want, +got) = %s", diff)
+			}
+		})
+	}
+}
+
+func TestWithInstalledIngressClasses(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	indexer.Add(&v1alpha1.IngressClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "installed.ingress.networking.knative.dev"},
+	})
+	ctx := WithInstalledIngressClasses(context.Background(), listers.NewIngressClassLister(indexer))
+
+	for class, valid := range map[string]bool{
+		"installed.ingress.networking.knative.dev": true,
+		"unknown.ingress.networking.knative.dev":   false,
+	} {
+		ing := &v1alpha1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "foo",
+				Namespace:   "bar",
+				Annotations: map[string]string{networking.IngressClassAnnotationKey: class},
+			},
+		}
+		err := ing.Validate(ctx)
+		if got := !strings.Contains(err.Error(), "is not installed"); got != valid {
+			t.Errorf("Validate(%s) = %v, want class valid: %v", class, err, valid)
+		}
+	}
+}
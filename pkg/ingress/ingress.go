@@ -20,6 +20,7 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -71,23 +72,99 @@ func InsertProbe(ing *v1alpha1.Ingress) (string, error) {
 	return hash, nil
 }
 
+// SortPaths sorts the given paths, in place, in the order in which they are to
+// be evaluated: by decreasing Priority, and then in their declared order.
+// Ingress implementations should translate the paths of each rule into
+// routes in this order.
+//
+// It may be called before or after InsertProbe: probe paths only match probe
+// requests and keep the Priority of the path they were copied from, so they
+// are evaluated in the same relative order as the paths themselves.
+func SortPaths(paths []v1alpha1.HTTPIngressPath) {
+	sort.SliceStable(paths, func(i, j int) bool {
+		return paths[i].Priority > paths[j].Priority
+	})
+}
+
 // HostsPerVisibility takes an Ingress and a map from visibility levels to a set of string keys,
 // it then returns a map from that key space to the hosts under that visibility.
 func HostsPerVisibility(ing *v1alpha1.Ingress, visibilityToKey map[v1alpha1.IngressVisibility]sets.String) map[string]sets.String {
-	output := make(map[string]sets.String, 2) // We currently have public and internal.
+	hostPorts := HostPortsPerVisibility(ing, visibilityToKey)
+	output := make(map[string]sets.String, len(hostPorts))
+	for key, hps := range hostPorts {
+		hosts := make(sets.String, len(hps))
+		for _, hp := range hps {
+			hosts.Insert(hp.Host)
+		}
+		output[key] = hosts
+	}
+	return output
+}
+
+// HostPort is a host served by an Ingress, along with the port it is served
+// on and the protocol of that port.  A zero Port stands for the standard
+// ports of the Ingress.
+type HostPort struct {
+	Host     string
+	Port     int32
+	Protocol v1alpha1.ListenerProtocol
+}
+
+// HostPortsPerVisibility is like HostsPerVisibility, but for implementations
+// that build a listener per port: it returns the hosts along with the port
+// they are served on, sorted by host and then port.
+func HostPortsPerVisibility(ing *v1alpha1.Ingress, visibilityToKey map[v1alpha1.IngressVisibility]sets.String) map[string][]HostPort {
+	seen := make(map[string]map[HostPort]struct{}, 2) // We currently have public and internal.
+	output := make(map[string][]HostPort, 2)
 	for _, rule := range ing.Spec.Rules {
-		for host := range ExpandedHosts(sets.NewString(rule.Hosts...)) {
+		for _, host := range ExpandedHosts(sets.NewString(rule.Hosts...)).List() {
+			hp := HostPort{Host: host, Port: rule.Port, Protocol: rule.Protocol}
 			for key := range visibilityToKey[rule.Visibility] {
-				if _, ok := output[key]; !ok {
-					output[key] = make(sets.String, len(rule.Hosts))
+				if _, ok := seen[key]; !ok {
+					seen[key] = make(map[HostPort]struct{}, len(rule.Hosts))
+				}
+				if _, ok := seen[key][hp]; ok {
+					continue
 				}
-				output[key].Insert(host)
+				seen[key][hp] = struct{}{}
+				output[key] = append(output[key], hp)
 			}
 		}
 	}
+	for _, hps := range output {
+		sort.Slice(hps, func(i, j int) bool {
+			if hps[i].Host != hps[j].Host {
+				return hps[i].Host < hps[j].Host
+			}
+			return hps[i].Port < hps[j].Port
+		})
+	}
 	return output
 }
 
+// AccessLog returns the access logging configuration of the Ingress, with the
+// fields it leaves unset filled in from the cluster-wide defaults in config.
+func AccessLog(ing *v1alpha1.Ingress, config *net.Config) v1alpha1.AccessLog {
+	al := v1alpha1.AccessLog{}
+	if ing.Spec.AccessLog != nil {
+		ing.Spec.AccessLog.DeepCopyInto(&al)
+	}
+	if al.Enabled == nil {
+		enabled := config.AccessLog
+		al.Enabled = &enabled
+	}
+	if al.Format == "" {
+		al.Format = config.AccessLogTemplate
+	}
+	if al.RequestHeaders == nil {
+		al.RequestHeaders = append([]string(nil), config.AccessLogRequestHeaders...)
+	}
+	if al.ResponseHeaders == nil {
+		al.ResponseHeaders = append([]string(nil), config.AccessLogResponseHeaders...)
+	}
+	return al
+}
+
 // ExpandedHosts sets up hosts for the short-names for cluster DNS names.
 func ExpandedHosts(hosts sets.String) sets.String {
 	allowedSuffixes := []string{
@@ -17,11 +17,19 @@ limitations under the License.
 package ingress
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	fuzz "github.com/google/gofuzz"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/util/sets"
+	net "knative.dev/networking/pkg"
+	"knative.dev/networking/pkg/apis/networking"
 	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/pkg/ptr"
 )
 
 func TestGetExpandedHosts(t *testing.T) {
@@ -77,6 +85,151 @@ func TestGetExpandedHosts(t *testing.T) {
 	}
 }
 
+func TestComputeHashBackendProtocol(t *testing.T) {
+	ing := &v1alpha1.Ingress{
+		Spec: v1alpha1.IngressSpec{
+			Rules: []v1alpha1.IngressRule{{
+				Hosts: []string{
+					"example.com",
+				},
+				HTTP: &v1alpha1.HTTPIngressRuleValue{
+					Paths: []v1alpha1.HTTPIngressPath{{
+						Splits: []v1alpha1.IngressBackendSplit{{
+							IngressBackend: v1alpha1.IngressBackend{
+								ServiceName: "blah",
+							},
+						}},
+					}},
+				},
+			}},
+		},
+	}
+	before, err := ComputeHash(ing)
+	if err != nil {
+		t.Fatal("ComputeHash() =", err)
+	}
+
+	ing.Spec.Rules[0].HTTP.Paths[0].Splits[0].Protocol = networking.ProtocolGRPC
+	after, err := ComputeHash(ing)
+	if err != nil {
+		t.Fatal("ComputeHash() =", err)
+	}
+	if before == after {
+		t.Error("ComputeHash() did not change when the backend protocol changed")
+	}
+}
+
+func TestComputeHashExtAuthz(t *testing.T) {
+	ing := &v1alpha1.Ingress{
+		Spec: v1alpha1.IngressSpec{
+			Rules: []v1alpha1.IngressRule{{
+				Hosts: []string{
+					"example.com",
+				},
+				HTTP: &v1alpha1.HTTPIngressRuleValue{
+					Paths: []v1alpha1.HTTPIngressPath{{
+						Splits: []v1alpha1.IngressBackendSplit{{
+							IngressBackend: v1alpha1.IngressBackend{
+								ServiceName: "blah",
+							},
+						}},
+					}},
+				},
+			}},
+		},
+	}
+	before, err := ComputeHash(ing)
+	if err != nil {
+		t.Fatal("ComputeHash() =", err)
+	}
+
+	ing.Spec.Rules[0].HTTP.Paths[0].ExtAuthz = &v1alpha1.ExtAuthz{
+		Service: v1alpha1.IngressBackend{
+			ServiceName: "authz",
+		},
+	}
+	after, err := ComputeHash(ing)
+	if err != nil {
+		t.Fatal("ComputeHash() =", err)
+	}
+	if before == after {
+		t.Error("ComputeHash() did not change when the external authorization was set")
+	}
+
+	ing.Spec.Rules[0].HTTP.Paths[0].ExtAuthz.FailureMode = v1alpha1.ExtAuthzFailureModeAllow
+	if got, err := ComputeHash(ing); err != nil {
+		t.Fatal("ComputeHash() =", err)
+	} else if got == after {
+		t.Error("ComputeHash() did not change when the external authorization changed")
+	}
+}
+
+// specLeaf is a settable value holding no other field, and its path.
+type specLeaf struct {
+	path  string
+	value reflect.Value
+}
+
+// specLeaves returns the leaves of v, in a deterministic order. The maps and
+// the types with their own JSON encoding are returned whole.
+func specLeaves(path string, v reflect.Value) []specLeaf {
+	if v.CanAddr() && v.Addr().Type().Implements(reflect.TypeOf((*json.Marshaler)(nil)).Elem()) {
+		return []specLeaf{{path, v}}
+	}
+	var leaves []specLeaf
+	switch v.Kind() {
+	case reflect.Ptr:
+		return specLeaves(path, v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			leaves = append(leaves, specLeaves(path+"."+v.Type().Field(i).Name, v.Field(i))...)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			leaves = append(leaves, specLeaves(fmt.Sprintf("%s[%d]", path, i), v.Index(i))...)
+		}
+	default:
+		leaves = append(leaves, specLeaf{path, v})
+	}
+	return leaves
+}
+
+func TestComputeHashCoversSpec(t *testing.T) {
+	// Every field of the spec, including those added later, is set.
+	f := fuzz.NewWithSeed(20200917).NilChance(0).NumElements(1, 1)
+	base := &v1alpha1.Ingress{}
+	f.Fuzz(&base.Spec)
+	want, err := ComputeHash(base)
+	if err != nil {
+		t.Fatal("ComputeHash() =", err)
+	}
+
+	// Changing any one of them changes the hash.
+	for i, leaf := range specLeaves("spec", reflect.ValueOf(&base.Spec)) {
+		ing := base.DeepCopy()
+		mutated := specLeaves("spec", reflect.ValueOf(&ing.Spec))[i].value
+		for equality.Semantic.DeepEqual(mutated.Interface(), leaf.value.Interface()) {
+			f.Fuzz(mutated.Addr().Interface())
+		}
+		if got, err := ComputeHash(ing); err != nil {
+			t.Fatal("ComputeHash() =", err)
+		} else if got == want {
+			t.Error("ComputeHash() did not change when changing", leaf.path)
+		}
+	}
+
+	// Anything but the spec and the name doesn't.
+	ing := base.DeepCopy()
+	f.Fuzz(&ing.Status)
+	ing.Labels = map[string]string{"foo": "bar"}
+	ing.Generation++
+	if got, err := ComputeHash(ing); err != nil {
+		t.Fatal("ComputeHash() =", err)
+	} else if got != want {
+		t.Error("ComputeHash() changed with the status or the metadata")
+	}
+}
+
 func TestInsertProbe(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -170,6 +323,93 @@ func TestInsertProbe(t *testing.T) {
 	}
 }
 
+func TestSortPaths(t *testing.T) {
+	path := func(p string, priority int) v1alpha1.HTTPIngressPath {
+		return v1alpha1.HTTPIngressPath{
+			Path:     p,
+			Priority: priority,
+		}
+	}
+	tests := []struct {
+		name  string
+		paths []v1alpha1.HTTPIngressPath
+		want  []v1alpha1.HTTPIngressPath
+	}{{
+		name: "empty",
+	}, {
+		name:  "declared order",
+		paths: []v1alpha1.HTTPIngressPath{path("/", 0), path("/foo", 0), path("/foo/bar", 0)},
+		want:  []v1alpha1.HTTPIngressPath{path("/", 0), path("/foo", 0), path("/foo/bar", 0)},
+	}, {
+		name:  "priority",
+		paths: []v1alpha1.HTTPIngressPath{path("/", 0), path("/foo", 1), path("/foo/bar", 2)},
+		want:  []v1alpha1.HTTPIngressPath{path("/foo/bar", 2), path("/foo", 1), path("/", 0)},
+	}, {
+		name:  "priority then declared order",
+		paths: []v1alpha1.HTTPIngressPath{path("/a", 0), path("/b", 5), path("/c", -1), path("/d", 5), path("/e", 0)},
+		want:  []v1alpha1.HTTPIngressPath{path("/b", 5), path("/d", 5), path("/a", 0), path("/e", 0), path("/c", -1)},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			SortPaths(test.paths)
+			if diff := cmp.Diff(test.want, test.paths); diff != "" {
+				t.Errorf("SortPaths (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
+
+func TestSortPathsWithProbe(t *testing.T) {
+	ing := func() *v1alpha1.Ingress {
+		return &v1alpha1.Ingress{
+			Spec: v1alpha1.IngressSpec{
+				Rules: []v1alpha1.IngressRule{{
+					Hosts: []string{"example.com"},
+					HTTP: &v1alpha1.HTTPIngressRuleValue{
+						Paths: []v1alpha1.HTTPIngressPath{{
+							Path: "/",
+						}, {
+							Path:     "/foo",
+							Priority: 1,
+						}},
+					},
+				}},
+			},
+		}
+	}
+	want := []string{"/foo", "/"}
+
+	// Sorting before and after inserting the probe must evaluate both the
+	// probe paths and the regular paths in the same order.
+	before, after := ing(), ing()
+	SortPaths(before.Spec.Rules[0].HTTP.Paths)
+	if _, err := InsertProbe(before); err != nil {
+		t.Fatal("InsertProbe() =", err)
+	}
+	if _, err := InsertProbe(after); err != nil {
+		t.Fatal("InsertProbe() =", err)
+	}
+	SortPaths(after.Spec.Rules[0].HTTP.Paths)
+
+	for _, ing := range []*v1alpha1.Ingress{before, after} {
+		var probes, regular []string
+		for _, p := range ing.Spec.Rules[0].HTTP.Paths {
+			if _, ok := p.Headers[net.HashHeaderName]; ok {
+				probes = append(probes, p.Path)
+			} else {
+				regular = append(regular, p.Path)
+			}
+		}
+		if !cmp.Equal(probes, want) {
+			t.Errorf("Probe paths = %v, wanted %v", probes, want)
+		}
+		if !cmp.Equal(regular, want) {
+			t.Errorf("Paths = %v, wanted %v", regular, want)
+		}
+	}
+}
+
 func TestHostsPerVisibility(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -264,3 +504,142 @@ func TestHostsPerVisibility(t *testing.T) {
 		})
 	}
 }
+
+func TestHostPortsPerVisibility(t *testing.T) {
+	rule := func(visibility v1alpha1.IngressVisibility, port int32, protocol v1alpha1.ListenerProtocol, hosts ...string) v1alpha1.IngressRule {
+		return v1alpha1.IngressRule{
+			Hosts:      hosts,
+			Visibility: visibility,
+			Port:       port,
+			Protocol:   protocol,
+			HTTP: &v1alpha1.HTTPIngressRuleValue{
+				Paths: []v1alpha1.HTTPIngressPath{{
+					Splits: []v1alpha1.IngressBackendSplit{{
+						IngressBackend: v1alpha1.IngressBackend{
+							ServiceName: "blah",
+						},
+					}},
+				}},
+			},
+		}
+	}
+	in := map[v1alpha1.IngressVisibility]sets.String{
+		v1alpha1.IngressVisibilityExternalIP:   sets.NewString("foo"),
+		v1alpha1.IngressVisibilityClusterLocal: sets.NewString("bar"),
+	}
+
+	tests := []struct {
+		name  string
+		rules []v1alpha1.IngressRule
+		want  map[string][]HostPort
+	}{{
+		name: "standard ports",
+		rules: []v1alpha1.IngressRule{
+			rule(v1alpha1.IngressVisibilityExternalIP, 0, "", "example.com"),
+			rule(v1alpha1.IngressVisibilityClusterLocal, 0, "", "foo.bar.svc.cluster.local"),
+		},
+		want: map[string][]HostPort{
+			"foo": {{Host: "example.com"}},
+			"bar": {
+				{Host: "foo.bar"},
+				{Host: "foo.bar.svc"},
+				{Host: "foo.bar.svc.cluster.local"},
+			},
+		},
+	}, {
+		name: "custom ports",
+		rules: []v1alpha1.IngressRule{
+			rule(v1alpha1.IngressVisibilityExternalIP, 9000, v1alpha1.ListenerProtocolHTTP, "legacy.example.com", "example.com"),
+			rule(v1alpha1.IngressVisibilityExternalIP, 8443, v1alpha1.ListenerProtocolHTTPS, "example.com"),
+			rule(v1alpha1.IngressVisibilityExternalIP, 0, "", "example.com"),
+		},
+		want: map[string][]HostPort{
+			"foo": {
+				{Host: "example.com"},
+				{Host: "example.com", Port: 8443, Protocol: v1alpha1.ListenerProtocolHTTPS},
+				{Host: "example.com", Port: 9000, Protocol: v1alpha1.ListenerProtocolHTTP},
+				{Host: "legacy.example.com", Port: 9000, Protocol: v1alpha1.ListenerProtocolHTTP},
+			},
+		},
+	}, {
+		name: "duplicate rules",
+		rules: []v1alpha1.IngressRule{
+			rule(v1alpha1.IngressVisibilityExternalIP, 9000, v1alpha1.ListenerProtocolHTTP, "example.com"),
+			rule(v1alpha1.IngressVisibilityExternalIP, 9000, v1alpha1.ListenerProtocolHTTP, "example.com"),
+		},
+		want: map[string][]HostPort{
+			"foo": {{Host: "example.com", Port: 9000, Protocol: v1alpha1.ListenerProtocolHTTP}},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ing := &v1alpha1.Ingress{
+				Spec: v1alpha1.IngressSpec{
+					Rules: test.rules,
+				},
+			}
+			got := HostPortsPerVisibility(ing, in)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("HostPortsPerVisibility (-want, +got) = %s", diff)
+			}
+		})
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	config := &net.Config{
+		AccessLog:               true,
+		AccessLogTemplate:       net.DefaultAccessLogTemplate,
+		AccessLogRequestHeaders: []string{"User-Agent"},
+	}
+	tests := []struct {
+		name string
+		in   *v1alpha1.AccessLog
+		want v1alpha1.AccessLog
+	}{{
+		name: "cluster defaults",
+		want: v1alpha1.AccessLog{
+			Enabled:        ptr.Bool(true),
+			Format:         net.DefaultAccessLogTemplate,
+			RequestHeaders: []string{"User-Agent"},
+		},
+	}, {
+		name: "disabled",
+		in: &v1alpha1.AccessLog{
+			Enabled: ptr.Bool(false),
+		},
+		want: v1alpha1.AccessLog{
+			Enabled:        ptr.Bool(false),
+			Format:         net.DefaultAccessLogTemplate,
+			RequestHeaders: []string{"User-Agent"},
+		},
+	}, {
+		name: "overrides",
+		in: &v1alpha1.AccessLog{
+			Format:          "{{.Status}}",
+			RequestHeaders:  []string{},
+			ResponseHeaders: []string{"Content-Type"},
+		},
+		want: v1alpha1.AccessLog{
+			Enabled:         ptr.Bool(true),
+			Format:          "{{.Status}}",
+			RequestHeaders:  []string{},
+			ResponseHeaders: []string{"Content-Type"},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ing := &v1alpha1.Ingress{
+				Spec: v1alpha1.IngressSpec{
+					AccessLog: test.in,
+				},
+			}
+			got := AccessLog(ing, config)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("AccessLog (-want, +got) = %v", diff)
+			}
+		})
+	}
+}
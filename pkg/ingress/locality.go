@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"sort"
+
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+)
+
+// ZoneWeights returns the percentage of the requests for a split that each
+// zone receives, when they are received by an Ingress instance in zone from.
+// The topology maps each zone to its number of healthy endpoints of the split.
+// Only zones with healthy endpoints are returned, and the percentages total to
+// 100 unless there are no healthy endpoints at all.
+func ZoneWeights(prefs *v1alpha1.LocalityPreferences, from string, topology map[string]int) map[string]int {
+	healthy := make(map[string]int, len(topology))
+	for zone, n := range topology {
+		if n > 0 {
+			healthy[zone] = n
+		}
+	}
+	if len(healthy) == 0 {
+		return map[string]int{}
+	}
+	if prefs == nil {
+		return apportion(healthy)
+	}
+
+	if prefs.PreferSameZone {
+		if _, ok := healthy[from]; ok {
+			return map[string]int{from: 100}
+		}
+		for _, zone := range prefs.FailoverZones {
+			if _, ok := healthy[zone]; ok {
+				return map[string]int{zone: 100}
+			}
+		}
+		// None of the preferred zones is healthy, spread over the others.
+		return apportion(healthy)
+	}
+
+	for _, d := range prefs.Distribution {
+		if d.From != from {
+			continue
+		}
+		shares := make(map[string]int, len(d.To))
+		for zone, pct := range d.To {
+			if _, ok := healthy[zone]; ok && pct > 0 {
+				shares[zone] = pct
+			}
+		}
+		if len(shares) > 0 {
+			return apportion(shares)
+		}
+		break
+	}
+	return apportion(healthy)
+}
+
+// apportion returns the percentages proportional to the given shares, rounded
+// using the largest remainder method so that they total to 100.  Ties are
+// broken by zone name to keep the result stable.
+func apportion(shares map[string]int) map[string]int {
+	total := 0
+	zones := make([]string, 0, len(shares))
+	for zone, n := range shares {
+		total += n
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	out := make(map[string]int, len(shares))
+	remainders := make(map[string]int, len(shares))
+	assigned := 0
+	for _, zone := range zones {
+		out[zone] = shares[zone] * 100 / total
+		remainders[zone] = shares[zone] * 100 % total
+		assigned += out[zone]
+	}
+	sort.SliceStable(zones, func(i, j int) bool {
+		return remainders[zones[i]] > remainders[zones[j]]
+	})
+	for i := 0; assigned < 100; i++ {
+		out[zones[i]]++
+		assigned++
+	}
+	return out
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+)
+
+func TestZoneWeights(t *testing.T) {
+	// A fake topology: the number of healthy endpoints per zone.
+	topology := map[string]int{
+		"zone-a": 2,
+		"zone-b": 1,
+		"zone-c": 0,
+		"zone-d": 3,
+	}
+
+	tests := []struct {
+		name     string
+		prefs    *v1alpha1.LocalityPreferences
+		from     string
+		topology map[string]int
+		want     map[string]int
+	}{{
+		name:     "no preferences",
+		from:     "zone-a",
+		topology: topology,
+		want:     map[string]int{"zone-a": 33, "zone-b": 17, "zone-d": 50},
+	}, {
+		name:     "no healthy endpoints",
+		prefs:    &v1alpha1.LocalityPreferences{PreferSameZone: true},
+		from:     "zone-a",
+		topology: map[string]int{"zone-a": 0},
+		want:     map[string]int{},
+	}, {
+		name:     "prefer same zone",
+		prefs:    &v1alpha1.LocalityPreferences{PreferSameZone: true},
+		from:     "zone-b",
+		topology: topology,
+		want:     map[string]int{"zone-b": 100},
+	}, {
+		name: "failover in order",
+		prefs: &v1alpha1.LocalityPreferences{
+			PreferSameZone: true,
+			FailoverZones:  []string{"zone-e", "zone-b", "zone-a"},
+		},
+		from:     "zone-c",
+		topology: topology,
+		want:     map[string]int{"zone-b": 100},
+	}, {
+		name: "failover exhausted",
+		prefs: &v1alpha1.LocalityPreferences{
+			PreferSameZone: true,
+			FailoverZones:  []string{"zone-e"},
+		},
+		from:     "zone-c",
+		topology: topology,
+		want:     map[string]int{"zone-a": 33, "zone-b": 17, "zone-d": 50},
+	}, {
+		name: "no healthy failover zones",
+		prefs: &v1alpha1.LocalityPreferences{
+			PreferSameZone: true,
+			FailoverZones:  []string{"zone-c", "zone-e"},
+		},
+		from:     "zone-c",
+		topology: topology,
+		want:     map[string]int{"zone-a": 33, "zone-b": 17, "zone-d": 50},
+	}, {
+		name: "distribution",
+		prefs: &v1alpha1.LocalityPreferences{
+			Distribution: []v1alpha1.LocalityDistribution{{
+				From: "zone-a",
+				To:   map[string]int{"zone-a": 80, "zone-b": 20},
+			}},
+		},
+		from:     "zone-a",
+		topology: topology,
+		want:     map[string]int{"zone-a": 80, "zone-b": 20},
+	}, {
+		name: "distribution skips unhealthy zones",
+		prefs: &v1alpha1.LocalityPreferences{
+			Distribution: []v1alpha1.LocalityDistribution{{
+				From: "zone-c",
+				To:   map[string]int{"zone-a": 30, "zone-b": 30, "zone-c": 40},
+			}},
+		},
+		from:     "zone-c",
+		topology: topology,
+		want:     map[string]int{"zone-a": 50, "zone-b": 50},
+	}, {
+		name: "distribution with no healthy zones",
+		prefs: &v1alpha1.LocalityPreferences{
+			Distribution: []v1alpha1.LocalityDistribution{{
+				From: "zone-a",
+				To:   map[string]int{"zone-c": 100},
+			}},
+		},
+		from:     "zone-a",
+		topology: topology,
+		want:     map[string]int{"zone-a": 33, "zone-b": 17, "zone-d": 50},
+	}, {
+		name: "distribution for another zone",
+		prefs: &v1alpha1.LocalityPreferences{
+			Distribution: []v1alpha1.LocalityDistribution{{
+				From: "zone-b",
+				To:   map[string]int{"zone-b": 100},
+			}},
+		},
+		from:     "zone-a",
+		topology: topology,
+		want:     map[string]int{"zone-a": 33, "zone-b": 17, "zone-d": 50},
+	}, {
+		name:     "rounding ties",
+		from:     "zone-a",
+		topology: map[string]int{"zone-a": 1, "zone-b": 1, "zone-c": 1},
+		want:     map[string]int{"zone-a": 34, "zone-b": 33, "zone-c": 33},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ZoneWeights(test.prefs, test.from, test.topology)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ZoneWeights (-want, +got) = %s", diff)
+			}
+		})
+	}
+}
@@ -162,6 +162,29 @@ const (
 	// reverse proxy fails to detect streaming (gRPC, e.g.).
 	FlushInterval = 20 * time.Millisecond
 
+	// AccessLogKey is the name of the configuration entry that specifies
+	// whether Ingresses log requests by default.
+	AccessLogKey = "accessLog"
+
+	// AccessLogTemplateKey is the name of the configuration entry that
+	// specifies the golang template string Ingresses use to format access
+	// log entries by default.
+	AccessLogTemplateKey = "accessLogTemplate"
+
+	// AccessLogRequestHeadersKey is the name of the configuration entry that
+	// specifies the comma separated list of request headers captured in
+	// access log entries by default.
+	AccessLogRequestHeadersKey = "accessLogRequestHeaders"
+
+	// AccessLogResponseHeadersKey is the name of the configuration entry that
+	// specifies the comma separated list of response headers captured in
+	// access log entries by default.
+	AccessLogResponseHeadersKey = "accessLogResponseHeaders"
+
+	// DefaultAccessLogTemplate is the default golang template to use when
+	// formatting access log entries.
+	DefaultAccessLogTemplate = "{{.Host}} {{.Path}} {{.Status}} {{.Latency}} {{.SplitTarget}} {{.Tag}}"
+
 	// VisibilityLabelKey is the label to indicate visibility of Route
 	// and KServices.  It can be an annotation too but since users are
 	// already using labels for domain, it probably best to keep this
@@ -188,18 +211,46 @@ type TagTemplateValues struct {
 	Tag  string
 }
 
+// AccessLogTemplateValues are the available properties people can choose from
+// in the golang template string used to format access log entries.
+type AccessLogTemplateValues struct {
+	// Host is the Host of the request.
+	Host string
+	// Path is the path of the request URL.
+	Path string
+	// Status is the status code of the response.
+	Status int
+	// Latency is the time between receiving the request and sending the
+	// last byte of the response.
+	Latency time.Duration
+	// SplitTarget is the `namespace/name:port` of the backend service the
+	// request was routed to.
+	SplitTarget string
+	// Tag is the value of the TagHeaderName header of the request.
+	Tag string
+	// RequestHeaders holds the values of the captured request headers.
+	RequestHeaders map[string]string
+	// ResponseHeaders holds the values of the captured response headers.
+	ResponseHeaders map[string]string
+}
+
 var (
 	templateCache *lru.Cache
+	// accessLogTemplateCache is separate from templateCache because the
+	// access log templates are parsed with other options.
+	accessLogTemplateCache *lru.Cache
 
 	// Verify the default templates are valid.
 	_ = template.Must(template.New("domain-template").Parse(DefaultDomainTemplate))
 	_ = template.Must(template.New("tag-template").Parse(DefaultTagTemplate))
+	_ = template.Must(template.New("access-log-template").Parse(DefaultAccessLogTemplate))
 )
 
 func init() {
 	// The only failure is due to negative size.
 	// Store ~10 latest templates per template type.
 	templateCache, _ = lru.New(10 * 2)
+	accessLogTemplateCache, _ = lru.New(10)
 }
 
 // Config contains the networking configuration defined in the
@@ -228,6 +279,21 @@ type Config struct {
 
 	// TagHeaderBasedRouting specifies if TagHeaderBasedRouting is enabled or not.
 	TagHeaderBasedRouting bool
+
+	// AccessLog specifies if Ingresses log requests by default.
+	AccessLog bool
+
+	// AccessLogTemplate is the golang text template Ingresses use by
+	// default to format access log entries.
+	AccessLogTemplate string
+
+	// AccessLogRequestHeaders is the list of request headers Ingresses
+	// capture in access log entries by default.
+	AccessLogRequestHeaders []string
+
+	// AccessLogResponseHeaders is the list of response headers Ingresses
+	// capture in access log entries by default.
+	AccessLogResponseHeaders []string
 }
 
 // HTTPProtocol indicates a type of HTTP endpoint behavior
@@ -253,6 +319,7 @@ func defaultConfig() *Config {
 		TagTemplate:             DefaultTagTemplate,
 		AutoTLS:                 false,
 		HTTPProtocol:            HTTPEnabled,
+		AccessLogTemplate:       DefaultAccessLogTemplate,
 	}
 }
 
@@ -272,6 +339,7 @@ func NewConfigFromMap(data map[string]string) (*Config, error) {
 		cm.AsString(DefaultCertificateClassKey, &nc.DefaultCertificateClass),
 		cm.AsString(DomainTemplateKey, &nc.DomainTemplate),
 		cm.AsString(TagTemplateKey, &nc.TagTemplate),
+		cm.AsString(AccessLogTemplateKey, &nc.AccessLogTemplate),
 	); err != nil {
 		return nil, err
 	}
@@ -296,8 +364,18 @@ func NewConfigFromMap(data map[string]string) (*Config, error) {
 	}
 	templateCache.Add(nc.TagTemplate, t)
 
+	// Verify access-log-template and add to the cache.
+	t, err = ParseAccessLogTemplate(nc.AccessLogTemplate)
+	if err != nil {
+		return nil, err
+	}
+	accessLogTemplateCache.Add(nc.AccessLogTemplate, t)
+
 	nc.AutoTLS = strings.EqualFold(data[AutoTLSKey], "enabled")
 	nc.TagHeaderBasedRouting = strings.EqualFold(data[TagHeaderBasedRoutingKey], "enabled")
+	nc.AccessLog = strings.EqualFold(data[AccessLogKey], "enabled")
+	nc.AccessLogRequestHeaders = splitList(data[AccessLogRequestHeadersKey])
+	nc.AccessLogResponseHeaders = splitList(data[AccessLogResponseHeadersKey])
 
 	switch strings.ToLower(data[HTTPProtocolKey]) {
 	case "", string(HTTPEnabled):
@@ -313,6 +391,17 @@ func NewConfigFromMap(data map[string]string) (*Config, error) {
 	return nc, nil
 }
 
+// splitList splits a comma separated list, dropping empty entries.
+func splitList(list string) []string {
+	var out []string
+	for _, s := range strings.Split(list, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
 // GetDomainTemplate returns the golang Template from the config map
 // or panics (the value is validated during CM validation and at
 // this point guaranteed to be parseable).
@@ -380,6 +469,46 @@ func checkTagTemplate(t *template.Template) error {
 	return t.Execute(ioutil.Discard, data)
 }
 
+// GetAccessLogTemplate returns the go template for access log entries.
+func (c *Config) GetAccessLogTemplate() *template.Template {
+	if tt, ok := accessLogTemplateCache.Get(c.AccessLogTemplate); ok {
+		return tt.(*template.Template)
+	}
+	// Should not really happen outside of unit tests.
+	nt := template.Must(ParseAccessLogTemplate(c.AccessLogTemplate))
+	accessLogTemplateCache.Add(c.AccessLogTemplate, nt)
+	return nt
+}
+
+// ParseAccessLogTemplate parses the given golang template string for access
+// log entries and verifies that it only refers to the properties of
+// AccessLogTemplateValues.
+func ParseAccessLogTemplate(format string) (*template.Template, error) {
+	if strings.TrimSpace(format) == "" {
+		return nil, errors.New("empty access log template")
+	}
+	t, err := template.New("access-log-template").Option("missingkey=zero").Parse(format)
+	if err != nil {
+		return nil, err
+	}
+	// Do a test run of applying the template, and see if we
+	// produce a result without error.
+	data := AccessLogTemplateValues{
+		Host:            "foo.bar.baz.com",
+		Path:            "/",
+		Status:          http.StatusOK,
+		Latency:         time.Second,
+		SplitTarget:     "bar/foo:80",
+		Tag:             "v2",
+		RequestHeaders:  map[string]string{},
+		ResponseHeaders: map[string]string{},
+	}
+	if err := t.Execute(ioutil.Discard, data); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
 // IsKubeletProbe returns true if the request is a Kubernetes probe.
 func IsKubeletProbe(r *http.Request) bool {
 	return strings.HasPrefix(r.Header.Get("User-Agent"), KubeProbeUAPrefix) ||
@@ -180,6 +180,35 @@ func TestConfiguration(t *testing.T) {
 			c.HTTPProtocol = HTTPRedirected
 			return c
 		}(),
+	}, {
+		name: "network configuration with access log",
+		data: map[string]string{
+			AccessLogKey:                "Enabled",
+			AccessLogTemplateKey:        `{{.Status}} {{index .RequestHeaders "User-Agent"}}`,
+			AccessLogRequestHeadersKey:  "User-Agent, X-Request-Id",
+			AccessLogResponseHeadersKey: "Content-Type",
+		},
+		wantErr: false,
+		wantConfig: func() *Config {
+			c := defaultConfig()
+			c.AccessLog = true
+			c.AccessLogTemplate = `{{.Status}} {{index .RequestHeaders "User-Agent"}}`
+			c.AccessLogRequestHeaders = []string{"User-Agent", "X-Request-Id"}
+			c.AccessLogResponseHeaders = []string{"Content-Type"}
+			return c
+		}(),
+	}, {
+		name:    "network configuration with blank access log template",
+		wantErr: true,
+		data: map[string]string{
+			AccessLogTemplateKey: " ",
+		},
+	}, {
+		name:    "network configuration with bad access log variable",
+		wantErr: true,
+		data: map[string]string{
+			AccessLogTemplateKey: "{{.Hostname}}",
+		},
 	}, {
 		name: "network configuration with HTTPProtocol bad",
 		data: map[string]string{
@@ -266,6 +295,34 @@ func TestTemplateCaching(t *testing.T) {
 	}
 }
 
+func TestAccessLogTemplateCaching(t *testing.T) {
+	// The same template as tag and access log template is parsed with the
+	// options of each.
+	const sharedTemplate = "{{.Tag}}-v1"
+	actualConfig, err := NewConfigFromMap(map[string]string{
+		TagTemplateKey:       sharedTemplate,
+		AccessLogTemplateKey: sharedTemplate,
+	})
+	if err != nil {
+		t.Fatal("Config parsing failure =", err)
+	}
+
+	var buf bytes.Buffer
+	if err := actualConfig.GetAccessLogTemplate().Execute(&buf, map[string]string{}); err != nil {
+		t.Fatal("Execute() =", err)
+	}
+	if got, want := buf.String(), "-v1"; got != want {
+		t.Errorf("Access log = %q, want: %q", got, want)
+	}
+	buf.Reset()
+	if err := actualConfig.GetTagTemplate().Execute(&buf, map[string]string{}); err != nil {
+		t.Fatal("Execute() =", err)
+	}
+	if got, want := buf.String(), "<no value>-v1"; got != want {
+		t.Errorf("Tag = %q, want: %q", got, want)
+	}
+}
+
 func TestAnnotationsInDomainTemplate(t *testing.T) {
 	networkConfigTests := []struct {
 		name               string
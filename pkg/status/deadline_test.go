@@ -0,0 +1,268 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zaptest"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	network "knative.dev/networking/pkg"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/networking/pkg/ingress"
+)
+
+const probeDeadline = 5 * time.Minute
+
+// deadlineTargets returns a ready ProbeTarget and one that never becomes ready
+// for the Ingress version with the given hash, along with the address of the
+// latter.
+func deadlineTargets(t *testing.T, hash string) ([]ProbeTarget, string) {
+	t.Helper()
+	readyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(network.HashHeaderName, hash)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(readyServer.Close)
+	pendingServer := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(pendingServer.Close)
+
+	var targets []ProbeTarget
+	for _, ts := range []*httptest.Server{readyServer, pendingServer} {
+		tsURL, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL %q: %v", ts.URL, err)
+		}
+		targets = append(targets, ProbeTarget{
+			PodIPs:  sets.NewString(tsURL.Hostname()),
+			PodPort: tsURL.Port(),
+			URLs:    []*url.URL{tsURL},
+		})
+	}
+	return targets, net.JoinHostPort(targets[1].PodIPs.List()[0], targets[1].PodPort)
+}
+
+// waitForDeadlineTimer waits until the deadline of the probing is waiting on
+// the fake clock, or not, depending on want.
+func waitForDeadlineTimer(t *testing.T, fakeClock *clock.FakeClock, want bool) {
+	t.Helper()
+	if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return fakeClock.HasWaiters() == want, nil
+	}); err != nil {
+		t.Fatalf("HasWaiters() = %t, want: %t", !want, want)
+	}
+}
+
+func TestProbeDeadlineExceeded(t *testing.T) {
+	ing := ingTemplate.DeepCopy()
+	hash, err := ingress.InsertProbe(ing.DeepCopy())
+	if err != nil {
+		t.Fatal("Failed to insert probe:", err)
+	}
+	targets, pendingAddr := deadlineTargets(t, hash)
+
+	fakeClock := clock.NewFakeClock(time.Now())
+	ready := make(chan *v1alpha1.Ingress, 1)
+	failed := make(chan *v1alpha1.Ingress, 1)
+	prober := NewProber(
+		zaptest.NewLogger(t).Sugar(),
+		fakeProbeTargetLister(targets),
+		DefaultProbeVerifier,
+		func(ing *v1alpha1.Ingress) {
+			ready <- ing
+		},
+		WithInitialDelay(0),
+		WithProbeDeadline(probeDeadline, func(ing *v1alpha1.Ingress) {
+			failed <- ing
+		}),
+		func(m *Prober) {
+			m.clock = fakeClock
+		})
+
+	done := make(chan struct{})
+	cancelled := prober.Start(done)
+	defer func() {
+		close(done)
+		<-cancelled
+	}()
+
+	if ok, err := prober.IsReady(context.Background(), ing); err != nil || ok {
+		t.Fatalf("IsReady() = (%t, %v), want: (false, nil)", ok, err)
+	}
+
+	// Wait for the pending listener to fail at least once.
+	if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		result, err := prober.Readiness(context.Background(), ing)
+		return err == nil && result.PendingPods == 1 && len(result.LastErrors) == 1, err
+	}); err != nil {
+		t.Fatal("The pending listener was never probed:", err)
+	}
+
+	// The deadline isn't exceeded yet.
+	waitForDeadlineTimer(t, fakeClock, true)
+	fakeClock.Step(probeDeadline - time.Second)
+	if ok, err := prober.IsReady(context.Background(), ing); err != nil || ok {
+		t.Fatalf("IsReady() = (%t, %v), want: (false, nil)", ok, err)
+	}
+
+	fakeClock.Step(time.Second)
+	select {
+	case got := <-failed:
+		if got != ing {
+			t.Errorf("Failure callback called with %v, want: %v", got, ing)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the failure callback")
+	}
+
+	ok, err := prober.IsReady(context.Background(), ing)
+	if ok {
+		t.Error("IsReady() = true, want: false")
+	}
+	var deadlineErr *DeadlineExceededError
+	if !errors.As(err, &deadlineErr) {
+		t.Fatalf("IsReady() error = %v, want a *DeadlineExceededError", err)
+	}
+	result := deadlineErr.Result
+	if !result.Failed || result.Ready || result.TotalPods != 2 || result.PendingPods != 1 {
+		t.Errorf("Result = %+v, want 1 failed Pod of 2", result)
+	}
+	if _, ok := result.LastErrors[pendingAddr]; !ok {
+		t.Errorf("LastErrors = %v, want an error for %s", result.LastErrors, pendingAddr)
+	}
+	if err.Error() != result.Message() {
+		t.Errorf("Error() = %q, want: %q", err.Error(), result.Message())
+	}
+
+	select {
+	case <-ready:
+		t.Error("Ready callback called for a failed Ingress")
+	default:
+	}
+
+	// A new version of the Ingress is probed again.
+	ing = ing.DeepCopy()
+	ing.Spec.Rules[0].Hosts[0] = "bar.foo.com"
+	if ok, err := prober.IsReady(context.Background(), ing); err != nil || ok {
+		t.Errorf("IsReady() = (%t, %v), want: (false, nil)", ok, err)
+	}
+}
+
+func TestProbeDeadlineReady(t *testing.T) {
+	ing := ingTemplate.DeepCopy()
+	hash, err := ingress.InsertProbe(ing.DeepCopy())
+	if err != nil {
+		t.Fatal("Failed to insert probe:", err)
+	}
+	targets, _ := deadlineTargets(t, hash)
+
+	fakeClock := clock.NewFakeClock(time.Now())
+	ready := make(chan *v1alpha1.Ingress, 1)
+	failed := make(chan *v1alpha1.Ingress, 1)
+	prober := NewProber(
+		zaptest.NewLogger(t).Sugar(),
+		fakeProbeTargetLister(targets[:1]),
+		DefaultProbeVerifier,
+		func(ing *v1alpha1.Ingress) {
+			ready <- ing
+		},
+		WithInitialDelay(0),
+		WithProbeDeadline(probeDeadline, func(ing *v1alpha1.Ingress) {
+			failed <- ing
+		}),
+		func(m *Prober) {
+			m.clock = fakeClock
+		})
+
+	done := make(chan struct{})
+	cancelled := prober.Start(done)
+	defer func() {
+		close(done)
+		<-cancelled
+	}()
+
+	if _, err := prober.IsReady(context.Background(), ing); err != nil {
+		t.Fatal("IsReady failed:", err)
+	}
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the ready callback")
+	}
+
+	// The deadline stops being enforced once the Ingress is ready.
+	waitForDeadlineTimer(t, fakeClock, false)
+	fakeClock.Step(2 * probeDeadline)
+	if ok, err := prober.IsReady(context.Background(), ing); err != nil || !ok {
+		t.Errorf("IsReady() = (%t, %v), want: (true, nil)", ok, err)
+	}
+	select {
+	case <-failed:
+		t.Error("Failure callback called for a ready Ingress")
+	default:
+	}
+}
+
+func TestProbeNoDeadline(t *testing.T) {
+	ing := ingTemplate.DeepCopy()
+	hash, err := ingress.InsertProbe(ing.DeepCopy())
+	if err != nil {
+		t.Fatal("Failed to insert probe:", err)
+	}
+	targets, _ := deadlineTargets(t, hash)
+
+	fakeClock := clock.NewFakeClock(time.Now())
+	prober := NewProber(
+		zaptest.NewLogger(t).Sugar(),
+		fakeProbeTargetLister(targets),
+		DefaultProbeVerifier,
+		func(*v1alpha1.Ingress) {},
+		WithInitialDelay(0),
+		func(m *Prober) {
+			m.clock = fakeClock
+		})
+
+	done := make(chan struct{})
+	cancelled := prober.Start(done)
+	defer func() {
+		close(done)
+		<-cancelled
+	}()
+
+	if ok, err := prober.IsReady(context.Background(), ing); err != nil || ok {
+		t.Fatalf("IsReady() = (%t, %v), want: (false, nil)", ok, err)
+	}
+	// Without a deadline, nothing waits on the clock and the Ingress is probed
+	// until it is ready.
+	if fakeClock.HasWaiters() {
+		t.Error("HasWaiters() = true, want: false")
+	}
+	fakeClock.Step(24 * time.Hour)
+	result, err := prober.Readiness(context.Background(), ing)
+	if err != nil || result.Failed || result.Ready {
+		t.Errorf("Readiness() = (%+v, %v), want pending Pods and no error", result, err)
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/metrics"
+)
+
+// The results of a probe, used as values of the result tag.
+const (
+	// probeResultReady is a probe showing that the Pod port is ready.
+	probeResultReady = "ready"
+	// probeResultHashMismatch is an HTTP 200 for another version of the Ingress.
+	probeResultHashMismatch = "hash_mismatch"
+	// probeResultUnavailable is an HTTP 404 or 503, the Ingress isn't configured yet.
+	probeResultUnavailable = "unavailable"
+	// probeResultAbandoned is a probe that was cancelled, or whose response
+	// was accepted by the verifier without showing that the Pod port is ready.
+	probeResultAbandoned = "abandoned"
+	// probeResultError is a probe that failed for any other reason.
+	probeResultError = "error"
+)
+
+var (
+	probeQueueDepthM = stats.Int64(
+		"probe_queue_depth",
+		"Number of probes waiting in the prober work queue",
+		stats.UnitDimensionless)
+	probeCountM = stats.Int64(
+		"probe_count",
+		"Number of probes sent by the prober",
+		stats.UnitDimensionless)
+	probeLatencyM = stats.Float64(
+		"probe_latencies",
+		"Latency of the probes sent by the prober",
+		stats.UnitMilliseconds)
+	timeToReadyM = stats.Float64(
+		"ingress_time_to_ready",
+		"Time from the first probe of a version of an Ingress until it is ready",
+		stats.UnitMilliseconds)
+	cancelledIngressesM = stats.Int64(
+		"ingress_probing_cancelled",
+		"Number of Ingress versions whose probing was cancelled before they were ready",
+		stats.UnitDimensionless)
+	trackedIngressesM = stats.Int64(
+		"prober_tracked_ingresses",
+		"Number of Ingress versions tracked by the prober",
+		stats.UnitDimensionless)
+	trackedPodPortsM = stats.Int64(
+		"prober_tracked_pod_ports",
+		"Number of Pod ports tracked by the prober",
+		stats.UnitDimensionless)
+
+	resultKey = tag.MustNewKey("result")
+
+	// 1ms to 10s for the probes, 10ms to 100s for the time to ready.
+	probeLatencyBuckets = metrics.Buckets125(1, 10000)
+	timeToReadyBuckets  = metrics.Buckets125(10, 100000)
+)
+
+func init() {
+	if err := view.Register(
+		&view.View{
+			Description: probeQueueDepthM.Description(),
+			Measure:     probeQueueDepthM,
+			Aggregation: view.LastValue(),
+		},
+		&view.View{
+			Description: probeCountM.Description(),
+			Measure:     probeCountM,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{resultKey},
+		},
+		&view.View{
+			Description: probeLatencyM.Description(),
+			Measure:     probeLatencyM,
+			Aggregation: view.Distribution(probeLatencyBuckets...),
+			TagKeys:     []tag.Key{resultKey},
+		},
+		&view.View{
+			Description: timeToReadyM.Description(),
+			Measure:     timeToReadyM,
+			Aggregation: view.Distribution(timeToReadyBuckets...),
+		},
+		&view.View{
+			Description: cancelledIngressesM.Description(),
+			Measure:     cancelledIngressesM,
+			Aggregation: view.Count(),
+		},
+		&view.View{
+			Description: trackedIngressesM.Description(),
+			Measure:     trackedIngressesM,
+			Aggregation: view.LastValue(),
+		},
+		&view.View{
+			Description: trackedPodPortsM.Description(),
+			Measure:     trackedPodPortsM,
+			Aggregation: view.LastValue(),
+		},
+	); err != nil {
+		panic(err)
+	}
+}
+
+// probeResult classifies a probe from the status code of its response, zero
+// when there was no response, and the outcome of its verification.
+func probeResult(statusCode int, ok bool, err error) string {
+	switch {
+	case ok && err == nil && statusCode == http.StatusOK:
+		return probeResultReady
+	case ok && err == nil:
+		return probeResultAbandoned
+	case statusCode == http.StatusOK:
+		return probeResultHashMismatch
+	case statusCode == http.StatusNotFound, statusCode == http.StatusServiceUnavailable:
+		return probeResultUnavailable
+	default:
+		return probeResultError
+	}
+}
+
+// recordProbe records a probe with the given result and latency.
+func recordProbe(result string, latency time.Duration) {
+	ctx, err := tag.New(context.Background(), tag.Upsert(resultKey, result))
+	if err != nil {
+		return
+	}
+	metrics.RecordBatch(ctx, probeCountM.M(1),
+		probeLatencyM.M(float64(latency)/float64(time.Millisecond)))
+}
+
+// recordQueueDepth records the depth of the prober work queue.
+func recordQueueDepth(depth int) {
+	metrics.Record(context.Background(), probeQueueDepthM.M(int64(depth)))
+}
+
+// recordTimeToReady records the time an Ingress version took to become ready.
+func recordTimeToReady(d time.Duration) {
+	metrics.Record(context.Background(), timeToReadyM.M(float64(d)/float64(time.Millisecond)))
+}
+
+// recordCancelled records an Ingress version whose probing was cancelled
+// before it was ready.
+func recordCancelled() {
+	metrics.Record(context.Background(), cancelledIngressesM.M(1))
+}
+
+// recordTracked records the number of Ingress versions and Pod ports tracked
+// by the prober.
+func recordTracked(ingresses, podPorts int) {
+	metrics.RecordBatch(context.Background(),
+		trackedIngressesM.M(int64(ingresses)),
+		trackedPodPortsM.M(int64(podPorts)))
+}
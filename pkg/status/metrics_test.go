@@ -0,0 +1,333 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap/zaptest"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	network "knative.dev/networking/pkg"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/networking/pkg/ingress"
+	"knative.dev/pkg/metrics"
+)
+
+// testExporter keeps the last data exported for each view.
+type testExporter struct {
+	mu   sync.Mutex
+	data map[string]*view.Data
+}
+
+func (e *testExporter) ExportView(d *view.Data) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.data[d.View.Name] = d
+}
+
+// rows returns the last rows exported for the view.
+func (e *testExporter) rows(name string) []*view.Row {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if d, ok := e.data[name]; ok {
+		return d.Rows
+	}
+	return nil
+}
+
+func newTestExporter(t *testing.T) *testExporter {
+	metrics.InitForTesting()
+	e := &testExporter{data: make(map[string]*view.Data)}
+	view.RegisterExporter(e)
+	view.SetReportingPeriod(10 * time.Millisecond)
+	t.Cleanup(func() {
+		view.UnregisterExporter(e)
+		view.SetReportingPeriod(time.Minute)
+	})
+	return e
+}
+
+// waitForRows waits until the rows exported for the view satisfy check.
+func waitForRows(t *testing.T, e *testExporter, name string, check func([]*view.Row) bool) {
+	t.Helper()
+	if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return check(e.rows(name)), nil
+	}); err != nil {
+		t.Fatalf("Rows of %s = %v, timed out waiting for the expected rows", name, e.rows(name))
+	}
+}
+
+// resultCount returns the count of the row tagged with the result.
+func resultCount(rows []*view.Row, result string) int64 {
+	for _, row := range rows {
+		for _, t := range row.Tags {
+			if t.Key == resultKey && t.Value == result {
+				if data, ok := row.Data.(*view.CountData); ok {
+					return data.Value
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func lastValue(rows []*view.Row) float64 {
+	if len(rows) != 1 {
+		return -1
+	}
+	if data, ok := rows[0].Data.(*view.LastValueData); ok {
+		return data.Value
+	}
+	return -1
+}
+
+func TestProbeResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		ok         bool
+		err        error
+		want       string
+	}{{
+		name:       "ready",
+		statusCode: http.StatusOK,
+		ok:         true,
+		want:       probeResultReady,
+	}, {
+		name:       "hash mismatch",
+		statusCode: http.StatusOK,
+		err:        errors.New("unexpected hash"),
+		want:       probeResultHashMismatch,
+	}, {
+		name:       "not found",
+		statusCode: http.StatusNotFound,
+		err:        errors.New("unexpected status code"),
+		want:       probeResultUnavailable,
+	}, {
+		name:       "unavailable",
+		statusCode: http.StatusServiceUnavailable,
+		err:        errors.New("unexpected status code"),
+		want:       probeResultUnavailable,
+	}, {
+		name:       "accepted without readiness",
+		statusCode: http.StatusFound,
+		ok:         true,
+		want:       probeResultAbandoned,
+	}, {
+		name: "no response",
+		err:  errors.New("connection refused"),
+		want: probeResultError,
+	}, {
+		name:       "unexpected status",
+		statusCode: http.StatusInternalServerError,
+		err:        errors.New("unexpected status code"),
+		want:       probeResultError,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := probeResult(test.statusCode, test.ok, test.err); got != test.want {
+				t.Errorf("probeResult() = %q, want: %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestProberMetrics(t *testing.T) {
+	exporter := newTestExporter(t)
+
+	ing := ingTemplate.DeepCopy()
+	hash, err := ingress.InsertProbe(ing.DeepCopy())
+	if err != nil {
+		t.Fatal("Failed to insert probe:", err)
+	}
+
+	// The first probe gets a mismatching hash, the second a 404 and the
+	// following ones succeed.
+	var (
+		mu       sync.Mutex
+		requests int
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		switch requests {
+		case 1:
+			w.Header().Set(network.HashHeaderName, "not-the-hash-you-are-looking-for")
+			w.WriteHeader(http.StatusOK)
+		case 2:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Header().Set(network.HashHeaderName, hash)
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer ts.Close()
+	tsURL, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL %q: %v", ts.URL, err)
+	}
+
+	ready := make(chan *v1alpha1.Ingress)
+	prober := NewProber(
+		zaptest.NewLogger(t).Sugar(),
+		fakeProbeTargetLister{{
+			PodIPs:  sets.NewString(tsURL.Hostname()),
+			PodPort: tsURL.Port(),
+			URLs:    []*url.URL{tsURL},
+		}},
+		DefaultProbeVerifier,
+		func(ing *v1alpha1.Ingress) {
+			ready <- ing
+		},
+		WithInitialDelay(0),
+		WithBackoff(time.Millisecond, time.Millisecond))
+
+	done := make(chan struct{})
+	cancelled := prober.Start(done)
+	defer func() {
+		close(done)
+		<-cancelled
+	}()
+
+	if _, err := prober.IsReady(context.Background(), ing); err != nil {
+		t.Fatal("IsReady failed:", err)
+	}
+
+	// One Ingress and one Pod port are tracked.
+	waitForRows(t, exporter, "prober_tracked_ingresses", func(rows []*view.Row) bool {
+		return lastValue(rows) == 1
+	})
+	waitForRows(t, exporter, "prober_tracked_pod_ports", func(rows []*view.Row) bool {
+		return lastValue(rows) == 1
+	})
+
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for probing to succeed.")
+	}
+
+	// Each probe is counted by result.
+	waitForRows(t, exporter, "probe_count", func(rows []*view.Row) bool {
+		return resultCount(rows, probeResultHashMismatch) >= 1 &&
+			resultCount(rows, probeResultUnavailable) >= 1 &&
+			resultCount(rows, probeResultReady) >= 1
+	})
+	waitForRows(t, exporter, "probe_latencies", func(rows []*view.Row) bool {
+		return len(rows) >= 3
+	})
+	waitForRows(t, exporter, "ingress_time_to_ready", func(rows []*view.Row) bool {
+		if len(rows) != 1 {
+			return false
+		}
+		data, ok := rows[0].Data.(*view.DistributionData)
+		return ok && data.Count >= 1
+	})
+	waitForRows(t, exporter, "probe_queue_depth", func(rows []*view.Row) bool {
+		return len(rows) == 1
+	})
+
+	// Forgetting the Ingress stops tracking it.
+	prober.CancelIngressProbing(ing)
+	waitForRows(t, exporter, "prober_tracked_ingresses", func(rows []*view.Row) bool {
+		return lastValue(rows) == 0
+	})
+}
+
+// recordedCount returns the number of measurements recorded for the view.
+func recordedCount(t *testing.T, name string) int64 {
+	t.Helper()
+	rows, err := view.RetrieveData(name)
+	if err != nil {
+		t.Fatalf("Failed to retrieve the data of %s: %v", name, err)
+	}
+	var count int64
+	for _, row := range rows {
+		switch data := row.Data.(type) {
+		case *view.CountData:
+			count += data.Value
+		case *view.DistributionData:
+			count += data.Count
+		}
+	}
+	return count
+}
+
+func TestProberCancellationMetrics(t *testing.T) {
+	newTestExporter(t)
+
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+	tsURL, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL %q: %v", ts.URL, err)
+	}
+
+	ready := make(chan *v1alpha1.Ingress)
+	prober := NewProber(
+		zaptest.NewLogger(t).Sugar(),
+		fakeProbeTargetLister{{
+			PodIPs:  sets.NewString(tsURL.Hostname()),
+			PodPort: tsURL.Port(),
+			URLs:    []*url.URL{tsURL},
+		}},
+		DefaultProbeVerifier,
+		func(ing *v1alpha1.Ingress) {
+			ready <- ing
+		},
+		WithInitialDelay(0))
+
+	done := make(chan struct{})
+	cancelled := prober.Start(done)
+	defer func() {
+		close(done)
+		<-cancelled
+	}()
+
+	timeToReady := recordedCount(t, "ingress_time_to_ready")
+	cancelledIngresses := recordedCount(t, "ingress_probing_cancelled")
+
+	ing := ingTemplate.DeepCopy()
+	if _, err := prober.IsReady(context.Background(), ing); err != nil {
+		t.Fatal("IsReady failed:", err)
+	}
+	prober.CancelIngressProbing(ing)
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the cancellation.")
+	}
+
+	// The cancelled Ingress is counted, but not as ready.
+	if got, want := recordedCount(t, "ingress_probing_cancelled"), cancelledIngresses+1; got != want {
+		t.Errorf("ingress_probing_cancelled = %d, want: %d", got, want)
+	}
+	if got := recordedCount(t, "ingress_time_to_ready"); got != timeToReady {
+		t.Errorf("ingress_time_to_ready count = %d, want: %d", got, timeToReady)
+	}
+}
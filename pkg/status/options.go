@@ -0,0 +1,137 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"net/http"
+	"time"
+
+	"golang.org/x/time/rate"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+)
+
+// ProberOption configures a Prober created by NewProber.
+type ProberOption func(*Prober)
+
+// TransportFactory returns the transport used to probe the Pod at the given IP
+// and port, which speaks the given protocol.  The probe requests are for the
+// URLs of the ProbeTargets, so the transport must connect to podIP:podPort
+// whatever the host of the URL.
+type TransportFactory func(podIP, podPort string, protocol networking.ProtocolType) http.RoundTripper
+
+// WithWorkers sets how many probes can be issued simultaneously.
+func WithWorkers(workers int) ProberOption {
+	return func(m *Prober) {
+		m.probeConcurrency = workers
+	}
+}
+
+// WithProbeTimeout sets the maximum amount of time a probe request may take,
+// including connecting to the Pod.
+func WithProbeTimeout(timeout time.Duration) ProberOption {
+	return func(m *Prober) {
+		m.probeTimeout = timeout
+	}
+}
+
+// WithInitialDelay sets the delay before the first probe of a Pod for a new
+// version of an Ingress.  It gives time for the change to propagate and
+// prevents unnecessary retries.
+func WithInitialDelay(delay time.Duration) ProberOption {
+	return func(m *Prober) {
+		m.initialDelay = delay
+	}
+}
+
+// WithBackoff sets the delays between the retries of a failing probe, which
+// grow exponentially from base up to max.
+func WithBackoff(base, max time.Duration) ProberOption {
+	return func(m *Prober) {
+		m.backoffBase, m.backoffMax = base, max
+	}
+}
+
+// WithRateLimit sets the overall rate of probes, in probes per second, with
+// bursts of up to burst probes.
+func WithRateLimit(limit rate.Limit, burst int) ProberOption {
+	return func(m *Prober) {
+		m.rateLimit, m.rateBurst = limit, burst
+	}
+}
+
+// WithTransportFactory sets the factory of the transports used to probe the
+// Pods, instead of one skipping TLS verification.
+func WithTransportFactory(factory TransportFactory) ProberOption {
+	return func(m *Prober) {
+		m.transportFactory = factory
+	}
+}
+
+// WithProbePath sets the path appended to the path of the probed URLs,
+// instead of network.ProbePath.
+func WithProbePath(path string) ProberOption {
+	return func(m *Prober) {
+		m.probePath = path
+	}
+}
+
+// WithTLSVerification enables the verification of the certificates served to
+// HTTPS probes.  The probes set SNI to the host of the probed URL and the
+// served certificate must cover that host.  Unless secretLister is nil, the
+// served certificate must also be the certificate of the Secret referenced by
+// the IngressTLS of the host.
+func WithTLSVerification(secretLister corev1listers.SecretLister) ProberOption {
+	return func(m *Prober) {
+		m.tlsVerifier = &tlsVerifier{secretLister: secretLister}
+	}
+}
+
+// WithStateTTL enables the eviction of the probing states of the Ingress
+// versions that are ready or failed and haven't been accessed by IsReady
+// within ttl, e.g. because the deletion of their Ingress was missed.  The
+// states are evicted between ttl and twice ttl after they were last accessed;
+// a later IsReady call for an evicted Ingress probes it again, so ttl should
+// exceed the resync period of the reconciler.  The states of the Ingress
+// versions still probed are kept, use WithProbeDeadline to bound their
+// probing.
+func WithStateTTL(ttl time.Duration) ProberOption {
+	return func(m *Prober) {
+		m.stateTTL = ttl
+	}
+}
+
+// WithMaxIdleConns sets how many idle connections are kept per Pod port by the
+// default transports.  The probes of a Pod port share its connections.
+func WithMaxIdleConns(conns int) ProberOption {
+	return func(m *Prober) {
+		m.maxIdleConns = conns
+	}
+}
+
+// WithProbeDeadline sets how long a version of an Ingress is probed before
+// giving up.  Past the deadline, failureCallback, unless nil, is called with
+// the Ingress and IsReady returns a DeadlineExceededError until a new version
+// of the Ingress is probed.
+func WithProbeDeadline(deadline time.Duration, failureCallback func(*v1alpha1.Ingress)) ProberOption {
+	return func(m *Prober) {
+		m.probeDeadline = deadline
+		m.failureCallback = failureCallback
+	}
+}
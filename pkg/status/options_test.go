@@ -0,0 +1,159 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/sets"
+	network "knative.dev/networking/pkg"
+	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/networking/pkg/ingress"
+)
+
+func TestProberOptions(t *testing.T) {
+	logger := zaptest.NewLogger(t).Sugar()
+
+	prober := NewProber(logger, fakeProbeTargetLister{}, nil, func(*v1alpha1.Ingress) {})
+	if got, want := prober.probeConcurrency, probeConcurrency; got != want {
+		t.Errorf("probeConcurrency = %d, want: %d", got, want)
+	}
+	if got, want := prober.probeTimeout, probeTimeout; got != want {
+		t.Errorf("probeTimeout = %v, want: %v", got, want)
+	}
+	if got, want := prober.initialDelay, initialDelay; got != want {
+		t.Errorf("initialDelay = %v, want: %v", got, want)
+	}
+	if got, want := prober.probePath, network.ProbePath; got != want {
+		t.Errorf("probePath = %q, want: %q", got, want)
+	}
+	if prober.transportFactory == nil {
+		t.Error("transportFactory = nil")
+	}
+
+	prober = NewProber(logger, fakeProbeTargetLister{}, nil, func(*v1alpha1.Ingress) {},
+		WithWorkers(3),
+		WithProbeTimeout(5*time.Second),
+		WithInitialDelay(time.Second),
+		WithBackoff(time.Millisecond, time.Minute),
+		WithRateLimit(rate.Limit(10), 20),
+		WithProbePath("/custom"))
+	if got, want := prober.probeConcurrency, 3; got != want {
+		t.Errorf("probeConcurrency = %d, want: %d", got, want)
+	}
+	if got, want := prober.probeTimeout, 5*time.Second; got != want {
+		t.Errorf("probeTimeout = %v, want: %v", got, want)
+	}
+	if got, want := prober.initialDelay, time.Second; got != want {
+		t.Errorf("initialDelay = %v, want: %v", got, want)
+	}
+	if prober.backoffBase != time.Millisecond || prober.backoffMax != time.Minute {
+		t.Errorf("backoff = (%v, %v), want: (%v, %v)", prober.backoffBase, prober.backoffMax, time.Millisecond, time.Minute)
+	}
+	if prober.rateLimit != rate.Limit(10) || prober.rateBurst != 20 {
+		t.Errorf("rate limit = (%v, %d), want: (%v, %d)", prober.rateLimit, prober.rateBurst, rate.Limit(10), 20)
+	}
+	if got, want := prober.probePath, "/custom"; got != want {
+		t.Errorf("probePath = %q, want: %q", got, want)
+	}
+}
+
+func TestProbeWithTransportFactory(t *testing.T) {
+	const probePath = "/custom-probe"
+
+	ing := ingTemplate.DeepCopy()
+	hash, err := ingress.InsertProbe(ing.DeepCopy())
+	if err != nil {
+		t.Fatal("Failed to insert probe:", err)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != probePath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set(network.HashHeaderName, hash)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	tsURL, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL %q: %v", ts.URL, err)
+	}
+
+	// The Pod IP and port are fake, the transport connects to the test server.
+	const podIP, podPort = "10.0.0.1", "8080"
+	dialed := make(chan string, 10)
+	factory := func(ip, port string, _ networking.ProtocolType) http.RoundTripper {
+		dialed <- net.JoinHostPort(ip, port)
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, tsURL.Host)
+		}
+		return transport
+	}
+
+	ready := make(chan *v1alpha1.Ingress)
+	prober := NewProber(
+		zaptest.NewLogger(t).Sugar(),
+		fakeProbeTargetLister{{
+			PodIPs:  sets.NewString(podIP),
+			PodPort: podPort,
+			URLs:    []*url.URL{tsURL},
+		}},
+		StrictProbeVerifier,
+		func(ing *v1alpha1.Ingress) {
+			ready <- ing
+		},
+		WithTransportFactory(factory),
+		WithProbePath(probePath),
+		WithInitialDelay(0))
+
+	done := make(chan struct{})
+	cancelled := prober.Start(done)
+	defer func() {
+		close(done)
+		<-cancelled
+	}()
+
+	ok, err := prober.IsReady(context.Background(), ing)
+	if err != nil {
+		t.Fatal("IsReady failed:", err)
+	}
+	if ok {
+		t.Fatal("IsReady() returned true")
+	}
+
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for probing to succeed.")
+	}
+
+	if got, want := <-dialed, net.JoinHostPort(podIP, podPort); got != want {
+		t.Errorf("Transport for %q, want: %q", got, want)
+	}
+}
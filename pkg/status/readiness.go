@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// maxMessageErrors is the maximum number of Pod errors listed by Message.
+const maxMessageErrors = 3
+
+// ReadinessResult describes the progress of the probing of a version of an
+// Ingress.  Pods are counted once per probed port.
+type ReadinessResult struct {
+	// Ready is true when all the Pods have been probed successfully.
+	Ready bool
+	// Failed is true when the Pods weren't all probed successfully within the
+	// probe deadline.  The pending Pods are no longer probed.
+	Failed bool
+	// Hash is the hash of the probed version of the Ingress.
+	Hash string
+	// TotalPods is the number of probed Pods.
+	TotalPods int
+	// PendingPods is the number of Pods that haven't been probed successfully yet.
+	PendingPods int
+	// LastErrors are the errors of the last probes of the pending Pods, by
+	// IP and port.  Pending Pods that haven't been probed yet are missing.
+	LastErrors map[string]string
+	// Elapsed is the time since the probing of this version began.
+	Elapsed time.Duration
+}
+
+// Message returns a human-readable description of the result, e.g. for
+// IngressStatus.MarkLoadBalancerNotReadyWithMessage.
+func (r ReadinessResult) Message() string {
+	if r.Ready {
+		return fmt.Sprintf("All %d Pods are ready", r.TotalPods)
+	}
+
+	var b strings.Builder
+	if r.Failed {
+		fmt.Fprintf(&b, "Load balancer not ready after %v: %d of %d Pods never became ready",
+			r.Elapsed.Round(time.Second), r.PendingPods, r.TotalPods)
+	} else {
+		fmt.Fprintf(&b, "Waiting for load balancer to be ready: %d of %d Pods pending after %v",
+			r.PendingPods, r.TotalPods, r.Elapsed.Round(time.Second))
+	}
+
+	addrs := make([]string, 0, len(r.LastErrors))
+	for addr := range r.LastErrors {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	for i, addr := range addrs {
+		if i == maxMessageErrors {
+			fmt.Fprintf(&b, " (and %d more)", len(addrs)-maxMessageErrors)
+			break
+		}
+		sep := ", "
+		if i == 0 {
+			sep = "; last errors: "
+		}
+		fmt.Fprintf(&b, "%s%s: %s", sep, addr, r.LastErrors[addr])
+	}
+	return b.String()
+}
+
+// DeadlineExceededError is returned by IsReady and Readiness when a version
+// of an Ingress wasn't ready within the probe deadline, see WithProbeDeadline.
+type DeadlineExceededError struct {
+	Result ReadinessResult
+}
+
+func (e *DeadlineExceededError) Error() string {
+	return e.Result.Message()
+}
+
+// result returns the ReadinessResult of the state at the given time.
+func (s *ingressState) result(now time.Time) ReadinessResult {
+	failed := atomic.LoadInt32(&s.failed) == 1
+	result := ReadinessResult{
+		Ready:      !failed && atomic.LoadInt32(&s.pendingCount) == 0,
+		Failed:     failed,
+		Hash:       s.hash,
+		TotalPods:  len(s.pods),
+		LastErrors: make(map[string]string),
+		Elapsed:    now.Sub(s.created),
+	}
+	for addr, pod := range s.pods {
+		if atomic.LoadInt32(&pod.pendingCount) <= 0 {
+			continue
+		}
+		result.PendingPods++
+		if err, ok := pod.lastError.Load().(string); ok {
+			result.LastErrors[addr.String()] = err
+		}
+	}
+	return result
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zaptest"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	network "knative.dev/networking/pkg"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/networking/pkg/ingress"
+)
+
+func TestReadinessResultMessage(t *testing.T) {
+	tests := []struct {
+		name   string
+		result ReadinessResult
+		want   string
+	}{{
+		name: "ready",
+		result: ReadinessResult{
+			Ready:     true,
+			TotalPods: 3,
+		},
+		want: "All 3 Pods are ready",
+	}, {
+		name: "not probed yet",
+		result: ReadinessResult{
+			TotalPods:   3,
+			PendingPods: 3,
+		},
+		want: "Waiting for load balancer to be ready: 3 of 3 Pods pending after 0s",
+	}, {
+		name: "errors",
+		result: ReadinessResult{
+			TotalPods:   3,
+			PendingPods: 2,
+			LastErrors: map[string]string{
+				"10.0.0.2:8080": "unexpected status code: want 200, got 404",
+				"10.0.0.1:8080": "unexpected hash",
+			},
+			Elapsed: 90*time.Second + 200*time.Millisecond,
+		},
+		want: "Waiting for load balancer to be ready: 2 of 3 Pods pending after 1m30s; " +
+			"last errors: 10.0.0.1:8080: unexpected hash, 10.0.0.2:8080: unexpected status code: want 200, got 404",
+	}, {
+		name: "too many errors",
+		result: ReadinessResult{
+			TotalPods:   5,
+			PendingPods: 5,
+			LastErrors: map[string]string{
+				"10.0.0.1:8080": "err1",
+				"10.0.0.2:8080": "err2",
+				"10.0.0.3:8080": "err3",
+				"10.0.0.4:8080": "err4",
+				"10.0.0.5:8080": "err5",
+			},
+			Elapsed: time.Minute,
+		},
+		want: "Waiting for load balancer to be ready: 5 of 5 Pods pending after 1m0s; " +
+			"last errors: 10.0.0.1:8080: err1, 10.0.0.2:8080: err2, 10.0.0.3:8080: err3 (and 2 more)",
+	}, {
+		name: "failed",
+		result: ReadinessResult{
+			Failed:      true,
+			TotalPods:   3,
+			PendingPods: 1,
+			LastErrors: map[string]string{
+				"10.0.0.1:8080": "unexpected hash",
+			},
+			Elapsed: 5 * time.Minute,
+		},
+		want: "Load balancer not ready after 5m0s: 1 of 3 Pods never became ready; " +
+			"last errors: 10.0.0.1:8080: unexpected hash",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.result.Message(); got != test.want {
+				t.Errorf("Message() = %q, want: %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestReadiness(t *testing.T) {
+	ing := ingTemplate.DeepCopy()
+	hash, err := ingress.InsertProbe(ing.DeepCopy())
+	if err != nil {
+		t.Fatal("Failed to insert probe:", err)
+	}
+
+	// One listener is ready, the other one isn't configured yet.
+	readyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(network.HashHeaderName, hash)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer readyServer.Close()
+	pendingServer := httptest.NewServer(http.NotFoundHandler())
+	defer pendingServer.Close()
+
+	var targets []ProbeTarget
+	for _, ts := range []*httptest.Server{readyServer, pendingServer} {
+		tsURL, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL %q: %v", ts.URL, err)
+		}
+		targets = append(targets, ProbeTarget{
+			PodIPs:  sets.NewString(tsURL.Hostname()),
+			PodPort: tsURL.Port(),
+			URLs:    []*url.URL{tsURL},
+		})
+	}
+	pendingAddr := net.JoinHostPort(targets[1].PodIPs.List()[0], targets[1].PodPort)
+
+	prober := NewProber(
+		zaptest.NewLogger(t).Sugar(),
+		fakeProbeTargetLister(targets),
+		DefaultProbeVerifier,
+		func(*v1alpha1.Ingress) {},
+		WithInitialDelay(0))
+
+	done := make(chan struct{})
+	cancelled := prober.Start(done)
+	defer func() {
+		close(done)
+		<-cancelled
+	}()
+
+	result, err := prober.Readiness(context.Background(), ing)
+	if err != nil {
+		t.Fatal("Readiness failed:", err)
+	}
+	if result.Ready || result.TotalPods != 2 || result.PendingPods != 2 {
+		t.Errorf("Readiness() = %+v, want 2 pending Pods of 2", result)
+	}
+	if wantHash := fmt.Sprintf("%x", mustComputeHash(t, ing)); result.Hash != wantHash {
+		t.Errorf("Hash = %q, want: %q", result.Hash, wantHash)
+	}
+
+	// Wait for the ready listener to be probed and the other one to fail.
+	if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		result, err = prober.Readiness(context.Background(), ing)
+		return err == nil && result.PendingPods == 1 && len(result.LastErrors) == 1, err
+	}); err != nil {
+		t.Fatalf("Readiness() = %+v, want 1 pending Pod with an error: %v", result, err)
+	}
+	if result.Ready || result.TotalPods != 2 {
+		t.Errorf("Readiness() = %+v, want 1 pending Pod of 2", result)
+	}
+	if got := result.LastErrors[pendingAddr]; !strings.Contains(got, "404") {
+		t.Errorf("LastErrors[%s] = %q, want a 404 error", pendingAddr, got)
+	}
+	if result.Elapsed <= 0 {
+		t.Errorf("Elapsed = %v, want > 0", result.Elapsed)
+	}
+	if got := result.Message(); !strings.Contains(got, pendingAddr) {
+		t.Errorf("Message() = %q, want it to mention %s", got, pendingAddr)
+	}
+
+	// IsReady agrees with Readiness.
+	if ok, err := prober.IsReady(context.Background(), ing); err != nil || ok {
+		t.Errorf("IsReady() = (%t, %v), want: (false, nil)", ok, err)
+	}
+}
+
+func mustComputeHash(t *testing.T, ing *v1alpha1.Ingress) [32]byte {
+	t.Helper()
+	bytes, err := ingress.ComputeHash(ing)
+	if err != nil {
+		t.Fatal("Failed to compute the hash:", err)
+	}
+	return bytes
+}
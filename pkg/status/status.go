@@ -19,6 +19,7 @@ package status
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -30,19 +31,25 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	"golang.org/x/net/http2"
 	"golang.org/x/time/rate"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 
 	network "knative.dev/networking/pkg"
+	"knative.dev/networking/pkg/apis/networking"
 	"knative.dev/networking/pkg/apis/networking/v1alpha1"
 	"knative.dev/networking/pkg/ingress"
+	"knative.dev/pkg/logging"
 	"knative.dev/pkg/logging/logkey"
 	"knative.dev/pkg/network/prober"
 )
 
+// The defaults of the ProberOptions.
 const (
 	// probeConcurrency defines how many probing calls can be issued simultaneously
 	probeConcurrency = 15
@@ -51,34 +58,64 @@ const (
 	// initialDelay defines the delay before enqueuing a probing request the first time.
 	// It gives times for the change to propagate and prevents unnecessary retries.
 	initialDelay = 200 * time.Millisecond
+	// backoffBase and backoffMax define the per item exponential backoff.
+	backoffBase = 50 * time.Millisecond
+	backoffMax  = 30 * time.Second
+	// rateLimit and rateBurst define the global rate limit of probes.
+	rateLimit = rate.Limit(50)
+	rateBurst = 100
+	// maxIdleConns defines how many idle connections are kept per Pod port.
+	maxIdleConns = 4
 )
 
-var dialContext = (&net.Dialer{Timeout: probeTimeout}).DialContext
-
 // ingressState represents the probing state of an Ingress
 type ingressState struct {
 	hash string
 	ing  *v1alpha1.Ingress
 
-	// pendingCount is the number of pods that haven't been successfully probed yet
+	// pendingCount is the number of pod ports that haven't been successfully probed yet
 	pendingCount int32
+	created      time.Time
 	lastAccessed time.Time
 
+	// pods are the states of the Pod ports probed for this version of the Ingress
+	pods map[podAddress]*podState
+
+	// finished is set to 1 once the Ingress version is ready or its probing failed
+	finished int32
+	// failed is set to 1 when the probing didn't succeed within the probe deadline
+	failed int32
+
 	cancel func()
 }
 
-// podState represents the probing state of a Pod (for a specific Ingress)
+// podState represents the probing state of a Pod port (for a specific Ingress)
 type podState struct {
-	// pendindCount is the number of probes for the Pod
+	// pendindCount is the number of probes for the Pod port
 	pendingCount int32
 
+	// lastError is the error of the last failed probe, a string
+	lastError atomic.Value
+
 	cancel func()
 }
 
+// podAddress identifies a port of a Pod, probes are tracked and cancelled
+// per podAddress.
+type podAddress struct {
+	ip   string
+	port string
+}
+
+func (a podAddress) String() string {
+	return net.JoinHostPort(a.ip, a.port)
+}
+
 // cancelContext is a pair of a Context and its cancel function
 type cancelContext struct {
-	context context.Context
-	cancel  func()
+	context      context.Context
+	cancel       func()
+	lastAccessed time.Time
 }
 
 type workItem struct {
@@ -88,6 +125,7 @@ type workItem struct {
 	url          *url.URL
 	podIP        string
 	podPort      string
+	protocol     networking.ProtocolType
 }
 
 // ProbeTarget contains the URLs to probes for a set of Pod IPs serving out of the same port.
@@ -96,6 +134,11 @@ type ProbeTarget struct {
 	PodPort string
 	Port    string
 	URLs    []*url.URL
+	// Protocol is the protocol spoken by PodPort, networking.ProtocolHTTP1 if
+	// empty: HTTP/1.1, or HTTP/2 when negotiated over TLS.  networking.ProtocolH2C
+	// and networking.ProtocolGRPC are probed with HTTP/2 over cleartext with
+	// prior knowledge, and networking.ProtocolH2 with HTTP/2 over TLS.
+	Protocol networking.ProtocolType
 }
 
 // ProbeTargetLister lists all the targets that requires probing.
@@ -107,6 +150,8 @@ type ProbeTargetLister interface {
 // Manager provides a way to check if an Ingress is ready
 type Manager interface {
 	IsReady(ctx context.Context, ing *v1alpha1.Ingress) (bool, error)
+	// Readiness is like IsReady, but describes the progress of the probing.
+	Readiness(ctx context.Context, ing *v1alpha1.Ingress) (ReadinessResult, error)
 }
 
 // Prober provides a way to check if a VirtualService is ready by probing the Envoy pods
@@ -117,38 +162,90 @@ type Prober struct {
 	// mu guards ingressStates and podContexts
 	mu            sync.Mutex
 	ingressStates map[string]*ingressState
-	podContexts   map[string]cancelContext
+	podContexts   map[podAddress]cancelContext
 
 	workQueue workqueue.RateLimitingInterface
 
 	targetLister ProbeTargetLister
 
+	verifier ProbeVerifier
+
 	readyCallback func(*v1alpha1.Ingress)
 
 	probeConcurrency int
+	probeTimeout     time.Duration
+	initialDelay     time.Duration
+	backoffBase      time.Duration
+	backoffMax       time.Duration
+	rateLimit        rate.Limit
+	rateBurst        int
+	transportFactory TransportFactory
+	maxIdleConns     int
+	probePath        string
+
+	// transports are shared by the probes of each Pod port
+	transports *transportPool
+
+	// stateTTL is how long the probing states are kept after they were last
+	// accessed, zero to keep them until they are cancelled.
+	stateTTL time.Duration
+
+	// probeDeadline is how long an Ingress version is probed before its
+	// probing fails, zero to probe it until it is ready.
+	probeDeadline   time.Duration
+	failureCallback func(*v1alpha1.Ingress)
+
+	clock clock.Clock
+
+	// tlsVerifier verifies the certificates served to HTTPS probes, nil to
+	// skip the verification.
+	tlsVerifier *tlsVerifier
 }
 
-// NewProber creates a new instance of Prober
+// NewProber creates a new instance of Prober.  The verifier decides whether
+// the probe responses show that an Ingress is ready, nil means
+// DefaultProbeVerifier.
 func NewProber(
 	logger *zap.SugaredLogger,
 	targetLister ProbeTargetLister,
-	readyCallback func(*v1alpha1.Ingress)) *Prober {
-	return &Prober{
-		logger:        logger,
-		ingressStates: make(map[string]*ingressState),
-		podContexts:   make(map[string]cancelContext),
-		workQueue: workqueue.NewNamedRateLimitingQueue(
-			workqueue.NewMaxOfRateLimiter(
-				// Per item exponential backoff
-				workqueue.NewItemExponentialFailureRateLimiter(50*time.Millisecond, 30*time.Second),
-				// Global rate limiter
-				&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(50), 100)},
-			),
-			"ProbingQueue"),
+	verifier ProbeVerifier,
+	readyCallback func(*v1alpha1.Ingress),
+	opts ...ProberOption) *Prober {
+	if verifier == nil {
+		verifier = DefaultProbeVerifier
+	}
+	m := &Prober{
+		logger:           logger,
+		ingressStates:    make(map[string]*ingressState),
+		podContexts:      make(map[podAddress]cancelContext),
 		targetLister:     targetLister,
+		verifier:         verifier,
 		readyCallback:    readyCallback,
 		probeConcurrency: probeConcurrency,
+		probeTimeout:     probeTimeout,
+		initialDelay:     initialDelay,
+		backoffBase:      backoffBase,
+		backoffMax:       backoffMax,
+		rateLimit:        rateLimit,
+		rateBurst:        rateBurst,
+		maxIdleConns:     maxIdleConns,
+		probePath:        network.ProbePath,
+		clock:            clock.RealClock{},
+	}
+	m.transportFactory = m.newTransport
+	for _, opt := range opts {
+		opt(m)
 	}
+	m.transports = newTransportPool(m.transportFactory)
+	m.workQueue = workqueue.NewNamedRateLimitingQueue(
+		workqueue.NewMaxOfRateLimiter(
+			// Per item exponential backoff
+			workqueue.NewItemExponentialFailureRateLimiter(m.backoffBase, m.backoffMax),
+			// Global rate limiter
+			&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(m.rateLimit, m.rateBurst)},
+		),
+		"ProbingQueue")
+	return m
 }
 
 func ingressKey(ing *v1alpha1.Ingress) string {
@@ -160,94 +257,117 @@ func ingressKey(ing *v1alpha1.Ingress) string {
 // will be called in the order of reconciliation. This means that if IsReady is called on an Ingress,
 // this Ingress is the latest known version and therefore anything related to older versions can be ignored.
 // Also, it means that IsReady is not called concurrently.
+// IsReady returns a *DeadlineExceededError when the Ingress wasn't ready within
+// the deadline set with WithProbeDeadline.
 func (m *Prober) IsReady(ctx context.Context, ing *v1alpha1.Ingress) (bool, error) {
+	result, err := m.Readiness(ctx, ing)
+	return result.Ready, err
+}
+
+// Readiness is like IsReady, but describes the progress of the probing of the
+// Ingress.
+func (m *Prober) Readiness(ctx context.Context, ing *v1alpha1.Ingress) (ReadinessResult, error) {
 	ingressKey := ingressKey(ing)
 	logger := m.logger.With(zap.String(logkey.Key, ingressKey))
 
 	bytes, err := ingress.ComputeHash(ing)
 	if err != nil {
-		return false, fmt.Errorf("failed to compute the hash of the Ingress: %w", err)
+		return ReadinessResult{}, fmt.Errorf("failed to compute the hash of the Ingress: %w", err)
 	}
 	hash := fmt.Sprintf("%x", bytes)
 
-	if ready, ok := func() (bool, bool) {
+	if result, ok := func() (ReadinessResult, bool) {
 		m.mu.Lock()
 		defer m.mu.Unlock()
 		if state, ok := m.ingressStates[ingressKey]; ok {
 			if state.hash == hash {
-				state.lastAccessed = time.Now()
-				return atomic.LoadInt32(&state.pendingCount) == 0, true
+				state.lastAccessed = m.clock.Now()
+				return state.result(state.lastAccessed), true
 			}
 
 			// Cancel the polling for the outdated version
 			state.cancel()
 			delete(m.ingressStates, ingressKey)
+			m.recordTrackedLocked()
 		}
-		return false, false
+		return ReadinessResult{}, false
 	}(); ok {
-		return ready, nil
+		if result.Failed {
+			return result, &DeadlineExceededError{Result: result}
+		}
+		return result, nil
 	}
 
 	ingCtx, cancel := context.WithCancel(context.Background())
+	now := m.clock.Now()
 	ingressState := &ingressState{
 		hash:         hash,
 		ing:          ing,
-		lastAccessed: time.Now(),
+		created:      now,
+		lastAccessed: now,
 		cancel:       cancel,
 	}
 
-	// Get the probe targets and group them by IP
+	// Get the probe targets and group them by IP and port
 	targets, err := m.targetLister.ListProbeTargets(ctx, ing)
 	if err != nil {
-		return false, err
+		return ReadinessResult{}, err
 	}
-	workItems := make(map[string][]*workItem)
+	workItems := make(map[podAddress][]*workItem)
 	for _, target := range targets {
 		for ip := range target.PodIPs {
+			addr := podAddress{ip: ip, port: target.PodPort}
 			for _, url := range target.URLs {
-				workItems[ip] = append(workItems[ip], &workItem{
+				workItems[addr] = append(workItems[addr], &workItem{
 					ingressState: ingressState,
 					url:          url,
 					podIP:        ip,
 					podPort:      target.PodPort,
+					protocol:     target.Protocol,
 				})
 			}
 		}
 	}
 
 	ingressState.pendingCount = int32(len(workItems))
+	ingressState.pods = make(map[podAddress]*podState, len(workItems))
 
-	for ip, ipWorkItems := range workItems {
-		// Get or create the context for that IP
-		ipCtx := func() context.Context {
+	for addr, addrWorkItems := range workItems {
+		// Get or create the context for that IP and port
+		addrCtx := func() context.Context {
 			m.mu.Lock()
 			defer m.mu.Unlock()
-			cancelCtx, ok := m.podContexts[ip]
+			cancelCtx, ok := m.podContexts[addr]
 			if !ok {
 				ctx, cancel := context.WithCancel(context.Background())
 				cancelCtx = cancelContext{
 					context: ctx,
 					cancel:  cancel,
 				}
-				m.podContexts[ip] = cancelCtx
+			}
+			cancelCtx.lastAccessed = m.clock.Now()
+			m.podContexts[addr] = cancelCtx
+			if !ok {
+				m.recordTrackedLocked()
 			}
 			return cancelCtx.context
 		}()
 
 		podCtx, cancel := context.WithCancel(ingCtx)
 		podState := &podState{
-			pendingCount: int32(len(ipWorkItems)),
+			pendingCount: int32(len(addrWorkItems)),
 			cancel:       cancel,
 		}
+		ingressState.pods[addr] = podState
 
-		// Quick and dirty way to join two contexts (i.e. podCtx is cancelled when either ingCtx or ipCtx are cancelled)
+		// Quick and dirty way to join two contexts (i.e. podCtx is cancelled when either ingCtx or addrCtx are cancelled)
 		go func() {
 			select {
 			case <-podCtx.Done():
 				// This is the actual context, there is nothing to do except
 				// break to avoid leaking this goroutine.
 				break
-			case <-ipCtx.Done():
+			case <-addrCtx.Done():
 				// Cancel podCtx
 				cancel()
 			}
@@ -259,21 +379,27 @@ func (m *Prober) IsReady(ctx context.Context, ing *v1alpha1.Ingress) (bool, erro
 			m.onProbingCancellation(ingressState, podState)
 		}()
 
-		for _, wi := range ipWorkItems {
+		for _, wi := range addrWorkItems {
 			wi.podState = podState
 			wi.context = podCtx
-			m.workQueue.AddAfter(wi, initialDelay)
+			m.workQueue.AddAfter(wi, m.initialDelay)
 			logger.Infof("Queuing probe for %s, IP: %s:%s (depth: %d)",
 				wi.url, wi.podIP, wi.podPort, m.workQueue.Len())
 		}
 	}
+	recordQueueDepth(m.workQueue.Len())
 
 	func() {
 		m.mu.Lock()
 		defer m.mu.Unlock()
 		m.ingressStates[ingressKey] = ingressState
+		m.recordTrackedLocked()
 	}()
-	return len(workItems) == 0, nil
+
+	if m.probeDeadline > 0 && len(workItems) > 0 {
+		go m.enforceDeadline(ingCtx, ingressState)
+	}
+	return ingressState.result(now), nil
 }
 
 // Start starts the Manager background operations
@@ -290,6 +416,15 @@ func (m *Prober) Start(done <-chan struct{}) chan struct{} {
 		}()
 	}
 
+	// Evict the stale probing states
+	if m.stateTTL > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.runSweeper(done)
+		}()
+	}
+
 	// Stop processing the queue when cancelled
 	go func() {
 		<-done
@@ -300,14 +435,18 @@ func (m *Prober) Start(done <-chan struct{}) chan struct{} {
 	ch := make(chan struct{})
 	go func() {
 		wg.Wait()
+		m.transports.disposeAll()
 		close(ch)
 	}()
 	return ch
 }
 
-// CancelIngressProbing cancels probing of the provided Ingress
-// TODO(#6270): Use cache.DeletedFinalStateUnknown.
+// CancelIngressProbing cancels probing of the provided Ingress, which may be
+// wrapped in a cache.DeletedFinalStateUnknown.
 func (m *Prober) CancelIngressProbing(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
 	if ing, ok := obj.(*v1alpha1.Ingress); ok {
 		key := ingressKey(ing)
 
@@ -316,23 +455,123 @@ func (m *Prober) CancelIngressProbing(obj interface{}) {
 		if state, ok := m.ingressStates[key]; ok {
 			state.cancel()
 			delete(m.ingressStates, key)
+			m.recordTrackedLocked()
 		}
 	}
 }
 
-// CancelPodProbing cancels probing of the provided Pod IP.
-//
-// TODO(#6269): make this cancellation based on Pod x port instead of just Pod.
+// CancelPodProbing cancels probing of all the ports of the provided Pod IP.
+// The Pod may be wrapped in a cache.DeletedFinalStateUnknown. Use
+// CancelEndpointProbing or CancelProbeTargetProbing to cancel probing of a
+// single port.
 func (m *Prober) CancelPodProbing(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
 	if pod, ok := obj.(*corev1.Pod); ok {
 		m.mu.Lock()
 		defer m.mu.Unlock()
 
-		if ctx, ok := m.podContexts[pod.Status.PodIP]; ok {
+		for addr, ctx := range m.podContexts {
+			if addr.ip == pod.Status.PodIP {
+				ctx.cancel()
+				delete(m.podContexts, addr)
+				m.transports.dispose(addr)
+			}
+		}
+		m.recordTrackedLocked()
+	}
+}
+
+// CancelEndpointProbing cancels probing of the provided port of the endpoint
+// address, the other ports of the same IP are still probed.
+func (m *Prober) CancelEndpointProbing(addr corev1.EndpointAddress, port string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cancelAddress(podAddress{ip: addr.IP, port: port})
+}
+
+// CancelProbeTargetProbing cancels probing of the PodPort of all the PodIPs
+// of the provided ProbeTarget.
+func (m *Prober) CancelProbeTargetProbing(target ProbeTarget) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for ip := range target.PodIPs {
+		m.cancelAddress(podAddress{ip: ip, port: target.PodPort})
+	}
+}
+
+// cancelAddress cancels probing of addr, m.mu must be held.
+func (m *Prober) cancelAddress(addr podAddress) {
+	if ctx, ok := m.podContexts[addr]; ok {
+		ctx.cancel()
+		delete(m.podContexts, addr)
+		m.transports.dispose(addr)
+		m.recordTrackedLocked()
+	}
+}
+
+// disposeUntrackedTransport disposes of the transports of the Pod port unless
+// it is still probed.
+func (m *Prober) disposeUntrackedTransport(addr podAddress) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.podContexts[addr]; !ok {
+		m.transports.dispose(addr)
+	}
+}
+
+// runSweeper evicts the stale probing states every stateTTL until done is
+// closed.
+func (m *Prober) runSweeper(done <-chan struct{}) {
+	ticker := m.clock.NewTicker(m.stateTTL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-ticker.C():
+			m.sweep(now)
+		}
+	}
+}
+
+// sweep evicts the finished Ingress versions not accessed within stateTTL
+// before now, and the Pod ports that are neither probed for the remaining
+// versions nor accessed within stateTTL before now.  The versions still probed
+// are kept, the probe deadline bounds their probing.
+func (m *Prober) sweep(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	inUse := make(map[podAddress]struct{})
+	for key, state := range m.ingressStates {
+		if atomic.LoadInt32(&state.finished) == 1 {
+			// The probing is over, nothing but IsReady uses the state anymore
+			if now.Sub(state.lastAccessed) > m.stateTTL {
+				m.logger.Infof("Evicting the probing state of %s, last accessed at %v", key, state.lastAccessed)
+				delete(m.ingressStates, key)
+			}
+			continue
+		}
+		for addr := range state.pods {
+			inUse[addr] = struct{}{}
+		}
+	}
+	for addr, ctx := range m.podContexts {
+		if _, ok := inUse[addr]; !ok && now.Sub(ctx.lastAccessed) > m.stateTTL {
 			ctx.cancel()
-			delete(m.podContexts, pod.Status.PodIP)
+			delete(m.podContexts, addr)
+			m.transports.dispose(addr)
 		}
 	}
+	m.recordTrackedLocked()
+}
+
+// recordTrackedLocked records the number of tracked Ingress versions and Pod
+// ports, m.mu must be held.
+func (m *Prober) recordTrackedLocked() {
+	recordTracked(len(m.ingressStates), len(m.podContexts))
 }
 
 // processWorkItem processes a single work item from workQueue.
@@ -354,46 +593,75 @@ func (m *Prober) processWorkItem() bool {
 	logger := m.logger.With(zap.String(logkey.Key, ingressKey(item.ingressState.ing)))
 	logger.Infof("Processing probe for %s, IP: %s:%s (depth: %d)",
 		item.url, item.podIP, item.podPort, m.workQueue.Len())
+	recordQueueDepth(m.workQueue.Len())
 
-	transport := http.DefaultTransport.(*http.Transport).Clone()
-	transport.TLSClientConfig = &tls.Config{
-		// We only want to know that the Gateway is configured, not that the configuration is valid.
-		// Therefore, we can safely ignore any TLS certificate validation.
-		InsecureSkipVerify: true,
-	}
-	transport.DialContext = func(ctx context.Context, network, addr string) (conn net.Conn, e error) {
-		// Requests with the IP as hostname and the Host header set do no pass client-side validation
-		// because the HTTP client validates that the hostname (not the Host header) matches the server
-		// TLS certificate Common Name or Alternative Names. Therefore, http.Request.URL is set to the
-		// hostname and it is substituted it here with the target IP.
-		return dialContext(ctx, network, net.JoinHostPort(item.podIP, item.podPort))
+	// In case of cancellation while queued, drop the work item before creating
+	// a transport for a Pod port that may be gone
+	if item.context.Err() != nil {
+		m.workQueue.Forget(obj)
+		return true
 	}
 
+	addr := podAddress{ip: item.podIP, port: item.podPort}
+	transport := m.transports.get(addr, item.protocol)
+
 	probeURL := deepCopy(item.url)
-	probeURL.Path = path.Join(probeURL.Path, network.ProbePath)
+	probeURL.Path = path.Join(probeURL.Path, m.probePath)
+	hostOption := prober.WithHost(probeURL.Host)
+	if probeURL.Scheme == "http" {
+		// Transports pool connections per URL host, so use the Pod address as
+		// the host to share the connections between all the probed hosts.  This
+		// isn't possible with TLS because the host is used for SNI.
+		probeURL.Host = addr.String()
+	}
 
-	ctx, cancel := context.WithTimeout(item.context, probeTimeout)
+	ctx, cancel := context.WithTimeout(item.context, m.probeTimeout)
 	defer cancel()
+	verifierCtx := logging.WithLogger(ctx, logger.With(
+		zap.String("url", item.url.String()), zap.String("ip", addr.String())))
+	verifier := m.probeVerifier(verifierCtx, item)
+	// statusCode is the status of the response, zero if there was none.
+	statusCode := 0
+	start := time.Now()
 	ok, err := prober.Do(
 		ctx,
 		transport,
 		probeURL.String(),
+		hostOption,
 		prober.WithHeader(network.UserAgentKey, network.IngressReadinessUserAgent),
 		prober.WithHeader(network.ProbeHeaderName, network.ProbeHeaderValue),
 		prober.WithHeader(network.HashHeaderName, network.HashHeaderValue),
-		m.probeVerifier(item))
+		prober.Verifier(func(r *http.Response, body []byte) (bool, error) {
+			if m.tlsVerifier != nil && probeURL.Scheme == "https" {
+				if err := m.tlsVerifier.verify(item.ingressState.ing, item.url.Hostname(), r.TLS); err != nil {
+					return false, err
+				}
+			}
+			statusCode = r.StatusCode
+			return verifier(r, body)
+		}))
+	latency := time.Since(start)
 
 	// In case of cancellation, drop the work item
 	select {
 	case <-item.context.Done():
+		recordProbe(probeResultAbandoned, latency)
 		m.workQueue.Forget(obj)
+		// The Pod port may have been cancelled while probing
+		m.disposeUntrackedTransport(addr)
 		return true
 	default:
 	}
 
+	recordProbe(probeResult(statusCode, ok, err), latency)
 	if err != nil || !ok {
+		if err == nil {
+			err = errors.New("the probe was not successful")
+		}
+		item.podState.lastError.Store(err.Error())
 		// In case of error, enqueue for retry
 		m.workQueue.AddRateLimited(obj)
+		recordQueueDepth(m.workQueue.Len())
 		logger.Errorf("Probing of %s failed, IP: %s:%s, ready: %t, error: %v (depth: %d)",
 			item.url, item.podIP, item.podPort, ok, err, m.workQueue.Len())
 	} else {
@@ -410,12 +678,61 @@ func (m *Prober) onProbingSuccess(ingressState *ingressState, podState *podState
 
 		// This is the last pod being successfully probed, the Ingress is ready
 		if atomic.AddInt32(&ingressState.pendingCount, -1) == 0 {
-			m.readyCallback(ingressState.ing)
+			m.onIngressReady(ingressState, true)
 		}
 	}
 }
 
+// onIngressReady is called when all the Pod ports of an Ingress version have
+// been probed successfully or have been cancelled.  probed is false when the
+// probing of the last pending Pod port was cancelled.
+func (m *Prober) onIngressReady(ingressState *ingressState, probed bool) {
+	if !atomic.CompareAndSwapInt32(&ingressState.finished, 0, 1) {
+		// The probing failed first
+		return
+	}
+	// Stop waiting for the probe deadline
+	ingressState.cancel()
+	if probed {
+		recordTimeToReady(m.clock.Since(ingressState.created))
+	} else {
+		recordCancelled()
+	}
+	m.readyCallback(ingressState.ing)
+}
+
+// enforceDeadline fails the probing of the Ingress version if it isn't ready
+// within probeDeadline of the beginning of its probing, unless ctx is
+// cancelled first.
+func (m *Prober) enforceDeadline(ctx context.Context, ingressState *ingressState) {
+	timer := m.clock.NewTimer(m.probeDeadline - m.clock.Since(ingressState.created))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return
+	case <-timer.C():
+	}
+
+	if !atomic.CompareAndSwapInt32(&ingressState.finished, 0, 1) {
+		// The Ingress version became ready first
+		return
+	}
+	atomic.StoreInt32(&ingressState.failed, 1)
+	// Stop probing, the cancellation doesn't make the Ingress version ready
+	// because it is finished.
+	ingressState.cancel()
+	m.logger.With(zap.String(logkey.Key, ingressKey(ingressState.ing))).Warnf(
+		"Probing of version %s failed: %s", ingressState.hash, ingressState.result(m.clock.Now()).Message())
+	if m.failureCallback != nil {
+		m.failureCallback(ingressState.ing)
+	}
+}
+
 func (m *Prober) onProbingCancellation(ingressState *ingressState, podState *podState) {
+	if atomic.LoadInt32(&ingressState.finished) == 1 {
+		// Keep the pending Pods of a failed Ingress version
+		return
+	}
 	for {
 		pendingCount := atomic.LoadInt32(&podState.pendingCount)
 		if pendingCount <= 0 {
@@ -427,48 +744,67 @@ func (m *Prober) onProbingCancellation(ingressState *ingressState, podState *pod
 		if atomic.CompareAndSwapInt32(&podState.pendingCount, pendingCount, 0) {
 			// This is the last pod being successfully probed, the Ingress is ready
 			if atomic.AddInt32(&ingressState.pendingCount, -1) == 0 {
-				m.readyCallback(ingressState.ing)
+				m.onIngressReady(ingressState, false)
 			}
 			return
 		}
 	}
 }
 
-func (m *Prober) probeVerifier(item *workItem) prober.Verifier {
-	logger := m.logger.With(zap.String(logkey.Key, ingressKey(item.ingressState.ing)))
-
-	return func(r *http.Response, _ []byte) (bool, error) {
-		// In the happy path, the probe request is forwarded to Activator or Queue-Proxy and the response (HTTP 200)
-		// contains the "K-Network-Hash" header that can be compared with the expected hash. If the hashes match,
-		// probing is successful, if they don't match, a new probe will be sent later.
-		// An HTTP 404/503 is expected in the case of the creation of a new Knative service because the rules will
-		// not be present in the Envoy config until the new VirtualService is applied.
-		// No information can be extracted from any other scenario (e.g. HTTP 302), therefore in that case,
-		// probing is assumed to be successful because it is better to say that an Ingress is Ready before it
-		// actually is Ready than never marking it as Ready. It is best effort.
-		switch r.StatusCode {
-		case http.StatusOK:
-			hash := r.Header.Get(network.HashHeaderName)
-			switch hash {
-			case "":
-				logger.Errorf("Probing of %s abandoned, IP: %s:%s: the response doesn't contain the %q header",
-					item.url, item.podIP, item.podPort, network.HashHeaderName)
-				return true, nil
-			case item.ingressState.hash:
-				return true, nil
-			default:
-				return false, fmt.Errorf("unexpected hash: want %q, got %q", item.ingressState.hash, hash)
-			}
+// newTransport is the default TransportFactory.
+func (m *Prober) newTransport(podIP, podPort string, protocol networking.ProtocolType) http.RoundTripper {
+	dialer := &net.Dialer{Timeout: m.probeTimeout}
+	// Requests with the IP as hostname and the Host header set do no pass client-side validation
+	// because the HTTP client validates that the hostname (not the Host header) matches the server
+	// TLS certificate Common Name or Alternative Names. Therefore, http.Request.URL is set to the
+	// hostname and it is substituted it here with the target IP.
+	podAddr := net.JoinHostPort(podIP, podPort)
+	// We only want to know that the Gateway is configured, not that the configuration is valid.
+	// Therefore, we can safely ignore any TLS certificate validation. WithTLSVerification checks
+	// the served certificates once the connection is established instead.
+	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+
+	switch protocol {
+	case networking.ProtocolH2C, networking.ProtocolGRPC:
+		return &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, _ string, _ *tls.Config) (net.Conn, error) {
+				return dialer.Dial(network, podAddr)
+			},
+		}
 
-		case http.StatusNotFound, http.StatusServiceUnavailable:
-			return false, fmt.Errorf("unexpected status code: want %v, got %v", http.StatusOK, r.StatusCode)
+	case networking.ProtocolH2:
+		return &http2.Transport{
+			TLSClientConfig: tlsConfig,
+			DialTLS: func(network, _ string, cfg *tls.Config) (net.Conn, error) {
+				conn, err := tls.DialWithDialer(dialer, network, podAddr, cfg)
+				if err != nil {
+					return nil, err
+				}
+				if p := conn.ConnectionState().NegotiatedProtocol; p != http2.NextProtoTLS {
+					conn.Close()
+					return nil, fmt.Errorf("unexpected ALPN protocol %q, want %q", p, http2.NextProtoTLS)
+				}
+				return conn, nil
+			},
+		}
 
-		default:
-			logger.Errorf("Probing of %s abandoned, IP: %s:%s: the response status is %v, expected one of: %v",
-				item.url, item.podIP, item.podPort, r.StatusCode,
-				[]int{http.StatusOK, http.StatusNotFound, http.StatusServiceUnavailable})
-			return true, nil
+	default:
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConns = m.maxIdleConns
+		transport.MaxIdleConnsPerHost = m.maxIdleConns
+		transport.TLSClientConfig = tlsConfig
+		transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, podAddr)
 		}
+		return transport
+	}
+}
+
+// probeVerifier adapts the ProbeVerifier of the Prober to the given work item.
+func (m *Prober) probeVerifier(ctx context.Context, item *workItem) prober.Verifier {
+	return func(r *http.Response, body []byte) (bool, error) {
+		return m.verifier.Verify(ctx, item.ingressState.hash, r, body)
 	}
 }
 
@@ -29,10 +29,13 @@ import (
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/networking/pkg/apis/networking"
 	"knative.dev/networking/pkg/apis/networking/v1alpha1"
 	"knative.dev/networking/pkg/ingress"
 
 	"go.uber.org/zap/zaptest"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	network "knative.dev/networking/pkg"
@@ -112,6 +115,7 @@ func TestProbeAllHosts(t *testing.T) {
 			PodPort: strconv.Itoa(port),
 			URLs:    []*url.URL{tsURL},
 		}},
+		DefaultProbeVerifier,
 		func(ing *v1alpha1.Ingress) {
 			ready <- ing
 		})
@@ -235,6 +239,7 @@ func TestProbeLifecycle(t *testing.T) {
 			PodPort: strconv.Itoa(port),
 			URLs:    []*url.URL{tsURL},
 		}},
+		DefaultProbeVerifier,
 		func(ing *v1alpha1.Ingress) {
 			ready <- ing
 		})
@@ -330,6 +335,7 @@ func TestProbeListerFail(t *testing.T) {
 	prober := NewProber(
 		zaptest.NewLogger(t).Sugar(),
 		notFoundLister{},
+		DefaultProbeVerifier,
 		func(ing *v1alpha1.Ingress) {
 			ready <- ing
 		})
@@ -389,6 +395,7 @@ func TestCancelPodProbing(t *testing.T) {
 			PodPort: strconv.Itoa(port),
 			URLs:    []*url.URL{tsURL},
 		}},
+		DefaultProbeVerifier,
 		func(ing *v1alpha1.Ingress) {
 			ready <- ing
 		})
@@ -532,6 +539,7 @@ func TestPartialPodCancellation(t *testing.T) {
 			PodPort: strconv.Itoa(port),
 			URLs:    []*url.URL{tsURL},
 		}},
+		DefaultProbeVerifier,
 		func(ing *v1alpha1.Ingress) {
 			ready <- ing
 		})
@@ -577,6 +585,206 @@ func TestPartialPodCancellation(t *testing.T) {
 	}
 }
 
+func TestCancelPortProbing(t *testing.T) {
+	tests := []struct {
+		name   string
+		cancel func(*Prober, ProbeTarget)
+	}{{
+		name: "endpoint address",
+		cancel: func(prober *Prober, target ProbeTarget) {
+			prober.CancelEndpointProbing(v1.EndpointAddress{IP: target.PodIPs.List()[0]}, target.PodPort)
+		},
+	}, {
+		name: "probe target",
+		cancel: func(prober *Prober, target ProbeTarget) {
+			prober.CancelProbeTargetProbing(target)
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ing := ingTemplate.DeepCopy()
+			hash, err := ingress.InsertProbe(ing.DeepCopy())
+			if err != nil {
+				t.Fatal("Failed to insert probe:", err)
+			}
+
+			// The HTTP listener never becomes ready.
+			httpRequests := make(chan *http.Request, 100)
+			httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				httpRequests <- r
+				w.WriteHeader(http.StatusNotFound)
+			}))
+			defer httpServer.Close()
+
+			// The other listener of the same Pod becomes ready once httpsReady is closed.
+			httpsReady := make(chan struct{})
+			httpsRequests := make(chan *http.Request, 100)
+			httpsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				httpsRequests <- r
+				select {
+				case <-httpsReady:
+					w.Header().Set(network.HashHeaderName, hash)
+					w.WriteHeader(http.StatusOK)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer httpsServer.Close()
+
+			httpURL, err := url.Parse(httpServer.URL)
+			if err != nil {
+				t.Fatalf("Failed to parse URL %q: %v", httpServer.URL, err)
+			}
+			httpsURL, err := url.Parse(httpsServer.URL)
+			if err != nil {
+				t.Fatalf("Failed to parse URL %q: %v", httpsServer.URL, err)
+			}
+
+			// Both targets share the IP of the Pod.
+			targets := []ProbeTarget{{
+				PodIPs:  sets.NewString(httpURL.Hostname()),
+				PodPort: httpURL.Port(),
+				URLs:    []*url.URL{httpURL},
+			}, {
+				PodIPs:  sets.NewString(httpsURL.Hostname()),
+				PodPort: httpsURL.Port(),
+				URLs:    []*url.URL{httpsURL},
+			}}
+
+			ready := make(chan *v1alpha1.Ingress)
+			prober := NewProber(
+				zaptest.NewLogger(t).Sugar(),
+				fakeProbeTargetLister(targets),
+				DefaultProbeVerifier,
+				func(ing *v1alpha1.Ingress) {
+					ready <- ing
+				})
+
+			done := make(chan struct{})
+			cancelled := prober.Start(done)
+			defer func() {
+				close(done)
+				<-cancelled
+			}()
+
+			ok, err := prober.IsReady(context.Background(), ing)
+			if err != nil {
+				t.Fatal("IsReady failed:", err)
+			}
+			if ok {
+				t.Fatal("IsReady() returned true")
+			}
+
+			// Wait for both listeners to be probed.
+			for _, requests := range []chan *http.Request{httpRequests, httpsRequests} {
+				select {
+				case <-requests:
+				case <-time.After(5 * time.Second):
+					t.Fatal("Timed out waiting for a probe request.")
+				}
+			}
+
+			// Cancel probing of the HTTP listener only.
+			test.cancel(prober, targets[0])
+
+			// The other listener is still pending.
+			select {
+			case <-ready:
+				t.Fatal("Probing succeeded while it should not have succeeded")
+			default:
+			}
+
+			// The other listener is still probed and eventually becomes ready.
+			close(httpsReady)
+			select {
+			case <-ready:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Probing was not successful even after waiting")
+			}
+			select {
+			case <-httpsRequests:
+			default:
+				t.Error("The other listener was not probed after cancellation")
+			}
+		})
+	}
+}
+
+func TestCancelPodProbingAllPorts(t *testing.T) {
+	ing := ingTemplate.DeepCopy()
+
+	// Both listeners of the Pod never become ready.
+	requests := make(chan *http.Request, 100)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r
+		w.WriteHeader(http.StatusNotFound)
+	})
+	var targets []ProbeTarget
+	for i := 0; i < 2; i++ {
+		ts := httptest.NewServer(handler)
+		defer ts.Close()
+		tsURL, err := url.Parse(ts.URL)
+		if err != nil {
+			t.Fatalf("Failed to parse URL %q: %v", ts.URL, err)
+		}
+		targets = append(targets, ProbeTarget{
+			PodIPs:  sets.NewString(tsURL.Hostname()),
+			PodPort: tsURL.Port(),
+			URLs:    []*url.URL{tsURL},
+		})
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "gateway",
+		},
+		Status: v1.PodStatus{
+			PodIP: targets[0].PodIPs.List()[0],
+		},
+	}
+
+	ready := make(chan *v1alpha1.Ingress)
+	prober := NewProber(
+		zaptest.NewLogger(t).Sugar(),
+		fakeProbeTargetLister(targets),
+		DefaultProbeVerifier,
+		func(ing *v1alpha1.Ingress) {
+			ready <- ing
+		})
+
+	done := make(chan struct{})
+	cancelled := prober.Start(done)
+	defer func() {
+		close(done)
+		<-cancelled
+	}()
+
+	ok, err := prober.IsReady(context.Background(), ing)
+	if err != nil {
+		t.Fatal("IsReady failed:", err)
+	}
+	if ok {
+		t.Fatal("IsReady() returned true")
+	}
+
+	select {
+	case <-requests:
+		// Wait for the first probe request
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for a probe request.")
+	}
+
+	// Cancelling the Pod cancels the probing of all its ports.
+	prober.CancelPodProbing(pod)
+
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Probing was not successful even after waiting")
+	}
+}
+
 func TestCancelIngressProbing(t *testing.T) {
 	ing := ingTemplate.DeepCopy()
 	// Handler keeping track of received requests and mimicking an Ingress not ready
@@ -606,6 +814,7 @@ func TestCancelIngressProbing(t *testing.T) {
 			PodPort: strconv.Itoa(port),
 			URLs:    []*url.URL{tsURL},
 		}},
+		DefaultProbeVerifier,
 		func(ing *v1alpha1.Ingress) {
 			ready <- ing
 		})
@@ -673,100 +882,16 @@ func TestCancelIngressProbing(t *testing.T) {
 	}
 }
 
-func TestProbeVerifier(t *testing.T) {
-	const hash = "Hi! I am hash!"
-	prober := NewProber(zaptest.NewLogger(t).Sugar(), nil, nil)
-	verifier := prober.probeVerifier(&workItem{
-		ingressState: &ingressState{
-			ing: &v1alpha1.Ingress{
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: "foo",
-					Name:      "bar",
-				},
-			},
-			hash: hash,
-		},
-		podState: nil,
-		context:  nil,
-		url:      nil,
-		podIP:    "",
-		podPort:  "",
-	})
-	cases := []struct {
-		name string
-		resp *http.Response
-		want bool
-	}{{
-		name: "HTTP 200 matching hash",
-		resp: &http.Response{
-			StatusCode: http.StatusOK,
-			Header:     http.Header{network.HashHeaderName: []string{hash}},
-		},
-		want: true,
-	}, {
-		name: "HTTP 200 mismatching hash",
-		resp: &http.Response{
-			StatusCode: http.StatusOK,
-			Header:     http.Header{network.HashHeaderName: []string{"nope"}},
-		},
-		want: false,
-	}, {
-		name: "HTTP 200 missing header",
-		resp: &http.Response{
-			StatusCode: http.StatusOK,
-		},
-		want: true,
-	}, {
-		name: "HTTP 404",
-		resp: &http.Response{
-			StatusCode: http.StatusNotFound,
-		},
-		want: false,
-	}, {
-		name: "HTTP 503",
-		resp: &http.Response{
-			StatusCode: http.StatusServiceUnavailable,
-		},
-		want: false,
-	}, {
-		name: "HTTP 403",
-		resp: &http.Response{
-			StatusCode: http.StatusForbidden,
-		},
-		want: true,
-	}, {
-		name: "HTTP 503",
-		resp: &http.Response{
-			StatusCode: http.StatusServiceUnavailable,
-		},
-		want: false,
-	}, {
-		name: "HTTP 302",
-		resp: &http.Response{
-			StatusCode: http.StatusFound,
-		},
-		want: true,
-	}}
-
-	for _, c := range cases {
-		t.Run(c.name, func(t *testing.T) {
-			got, _ := verifier(c.resp, nil)
-			if got != c.want {
-				t.Errorf("got: %v, want: %v", got, c.want)
-			}
-		})
-	}
-}
-
 type fakeProbeTargetLister []ProbeTarget
 
 func (l fakeProbeTargetLister) ListProbeTargets(ctx context.Context, ing *v1alpha1.Ingress) ([]ProbeTarget, error) {
 	targets := []ProbeTarget{}
 	for _, target := range l {
 		newTarget := ProbeTarget{
-			PodIPs:  target.PodIPs,
-			PodPort: target.PodPort,
-			Port:    target.Port,
+			PodIPs:   target.PodIPs,
+			PodPort:  target.PodPort,
+			Port:     target.Port,
+			Protocol: target.Protocol,
 		}
 		for _, url := range target.URLs {
 			for _, host := range ing.Spec.Rules[0].Hosts {
@@ -785,3 +910,102 @@ type notFoundLister struct{}
 func (l notFoundLister) ListProbeTargets(ctx context.Context, ing *v1alpha1.Ingress) ([]ProbeTarget, error) {
 	return nil, errors.New("not found")
 }
+
+func TestProbeProtocols(t *testing.T) {
+	tests := []struct {
+		name      string
+		protocol  networking.ProtocolType
+		newServer func(http.Handler) *httptest.Server
+		wantProto int
+	}{{
+		name:      "default",
+		newServer: httptest.NewServer,
+		wantProto: 1,
+	}, {
+		name:      "http1",
+		protocol:  networking.ProtocolHTTP1,
+		newServer: httptest.NewServer,
+		wantProto: 1,
+	}, {
+		name:     "h2c",
+		protocol: networking.ProtocolH2C,
+		newServer: func(h http.Handler) *httptest.Server {
+			return httptest.NewServer(h2c.NewHandler(h, &http2.Server{}))
+		},
+		wantProto: 2,
+	}, {
+		name:     "grpc",
+		protocol: networking.ProtocolGRPC,
+		newServer: func(h http.Handler) *httptest.Server {
+			return httptest.NewServer(h2c.NewHandler(h, &http2.Server{}))
+		},
+		wantProto: 2,
+	}, {
+		name:     "h2",
+		protocol: networking.ProtocolH2,
+		newServer: func(h http.Handler) *httptest.Server {
+			ts := httptest.NewUnstartedServer(h)
+			ts.EnableHTTP2 = true
+			ts.StartTLS()
+			return ts
+		},
+		wantProto: 2,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ing := ingTemplate.DeepCopy()
+			hash, err := ingress.InsertProbe(ing.DeepCopy())
+			if err != nil {
+				t.Fatal("Failed to insert probe:", err)
+			}
+
+			protos := make(chan int, 100)
+			ts := test.newServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				protos <- r.ProtoMajor
+				w.Header().Set(network.HashHeaderName, hash)
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer ts.Close()
+			tsURL, err := url.Parse(ts.URL)
+			if err != nil {
+				t.Fatalf("Failed to parse URL %q: %v", ts.URL, err)
+			}
+
+			ready := make(chan *v1alpha1.Ingress)
+			prober := NewProber(
+				zaptest.NewLogger(t).Sugar(),
+				fakeProbeTargetLister{{
+					PodIPs:   sets.NewString(tsURL.Hostname()),
+					PodPort:  tsURL.Port(),
+					URLs:     []*url.URL{tsURL},
+					Protocol: test.protocol,
+				}},
+				StrictProbeVerifier,
+				func(ing *v1alpha1.Ingress) {
+					ready <- ing
+				},
+				WithInitialDelay(0))
+
+			done := make(chan struct{})
+			cancelled := prober.Start(done)
+			defer func() {
+				close(done)
+				<-cancelled
+			}()
+
+			if _, err := prober.IsReady(context.Background(), ing); err != nil {
+				t.Fatal("IsReady failed:", err)
+			}
+
+			select {
+			case <-ready:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Timed out waiting for probing to succeed.")
+			}
+			if got := <-protos; got != test.wantProto {
+				t.Errorf("HTTP version = %d, want: %d", got, test.wantProto)
+			}
+		})
+	}
+}
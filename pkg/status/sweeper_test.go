@@ -0,0 +1,338 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+	"go.uber.org/zap/zaptest"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	network "knative.dev/networking/pkg"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/networking/pkg/ingress"
+)
+
+// ignoreCurrent returns goleak options ignoring the top functions of the
+// goroutines already running, e.g. those left behind by other tests of the
+// package.
+func ignoreCurrent() []goleak.Option {
+	buf := make([]byte, 1<<20)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	var opts []goleak.Option
+	seen := sets.NewString()
+	scanner := bufio.NewScanner(bytes.NewReader(buf))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(buf))
+	header := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "goroutine ") {
+			header = true
+			continue
+		}
+		if header {
+			header = false
+			// The first line after the header is the top function and its arguments.
+			if idx := strings.LastIndex(line, "("); idx > 0 && !seen.Has(line[:idx]) {
+				seen.Insert(line[:idx])
+				opts = append(opts, goleak.IgnoreTopFunction(line[:idx]))
+			}
+		}
+	}
+	return opts
+}
+
+// trackedStates returns the number of Ingress versions and Pod ports tracked
+// by the prober.
+func trackedStates(prober *Prober) (int, int) {
+	prober.mu.Lock()
+	defer prober.mu.Unlock()
+	return len(prober.ingressStates), len(prober.podContexts)
+}
+
+func TestSweep(t *testing.T) {
+	const ttl = time.Minute
+	ing := ingTemplate.DeepCopy()
+	hash, err := ingress.InsertProbe(ing.DeepCopy())
+	if err != nil {
+		t.Fatal("Failed to insert probe:", err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(network.HashHeaderName, hash)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	tsURL, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL %q: %v", ts.URL, err)
+	}
+
+	ready := make(chan *v1alpha1.Ingress, 1)
+	prober := NewProber(
+		zaptest.NewLogger(t).Sugar(),
+		fakeProbeTargetLister{{
+			PodIPs:  sets.NewString(tsURL.Hostname()),
+			PodPort: tsURL.Port(),
+			URLs:    []*url.URL{tsURL},
+		}},
+		DefaultProbeVerifier,
+		func(ing *v1alpha1.Ingress) {
+			ready <- ing
+		},
+		WithInitialDelay(0),
+		WithStateTTL(ttl))
+
+	done := make(chan struct{})
+	cancelled := prober.Start(done)
+	defer func() {
+		close(done)
+		<-cancelled
+	}()
+
+	if _, err := prober.IsReady(context.Background(), ing); err != nil {
+		t.Fatal("IsReady failed:", err)
+	}
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for probing to succeed.")
+	}
+	// A Pod port only used by an evicted Ingress version.
+	func() {
+		prober.mu.Lock()
+		defer prober.mu.Unlock()
+		_, cancel := context.WithCancel(context.Background())
+		prober.podContexts[podAddress{ip: "198.51.100.3", port: "8080"}] = cancelContext{
+			cancel:       cancel,
+			lastAccessed: time.Now().Add(-2 * ttl),
+		}
+	}()
+
+	// Recently accessed states and Pod ports are kept.
+	prober.sweep(time.Now())
+	if ingresses, podPorts := trackedStates(prober); ingresses != 1 || podPorts != 1 {
+		t.Errorf("Tracked states = (%d, %d), want: (1, 1)", ingresses, podPorts)
+	}
+
+	// Accessing the state postpones its eviction.
+	prober.sweep(time.Now().Add(ttl / 2))
+	if _, err := prober.IsReady(context.Background(), ing); err != nil {
+		t.Fatal("IsReady failed:", err)
+	}
+	prober.sweep(time.Now().Add(ttl / 2))
+	if ingresses, _ := trackedStates(prober); ingresses != 1 {
+		t.Errorf("Tracked Ingress versions = %d, want: 1", ingresses)
+	}
+
+	// Stale finished states are evicted.
+	prober.sweep(time.Now().Add(2 * ttl))
+	if ingresses, podPorts := trackedStates(prober); ingresses != 0 || podPorts != 0 {
+		t.Errorf("Tracked states = (%d, %d), want: (0, 0)", ingresses, podPorts)
+	}
+}
+
+func TestSweepKeepsProbingStates(t *testing.T) {
+	const ttl = time.Minute
+	ing := ingTemplate.DeepCopy()
+	hash, err := ingress.InsertProbe(ing.DeepCopy())
+	if err != nil {
+		t.Fatal("Failed to insert probe:", err)
+	}
+	// The Ingress isn't configured until configured is closed.
+	configured := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-configured:
+			w.Header().Set(network.HashHeaderName, hash)
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	tsURL, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL %q: %v", ts.URL, err)
+	}
+
+	ready := make(chan *v1alpha1.Ingress, 1)
+	prober := NewProber(
+		zaptest.NewLogger(t).Sugar(),
+		fakeProbeTargetLister{{
+			PodIPs:  sets.NewString(tsURL.Hostname()),
+			PodPort: tsURL.Port(),
+			URLs:    []*url.URL{tsURL},
+		}},
+		DefaultProbeVerifier,
+		func(ing *v1alpha1.Ingress) {
+			ready <- ing
+		},
+		WithInitialDelay(0),
+		WithBackoff(time.Millisecond, 10*time.Millisecond),
+		WithStateTTL(ttl))
+
+	done := make(chan struct{})
+	cancelled := prober.Start(done)
+	defer func() {
+		close(done)
+		<-cancelled
+	}()
+
+	if _, err := prober.IsReady(context.Background(), ing); err != nil {
+		t.Fatal("IsReady failed:", err)
+	}
+
+	// The Ingress is probed for longer than the TTL without being accessed.
+	prober.sweep(time.Now().Add(2 * ttl))
+	if ingresses, podPorts := trackedStates(prober); ingresses != 1 || podPorts != 1 {
+		t.Errorf("Tracked states = (%d, %d), want: (1, 1)", ingresses, podPorts)
+	}
+
+	// And still becomes ready.
+	close(configured)
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for probing to succeed.")
+	}
+	if ok, err := prober.IsReady(context.Background(), ing); err != nil || !ok {
+		t.Errorf("IsReady() = (%t, %v), want: (true, nil)", ok, err)
+	}
+}
+
+func TestSweeperNoLeaks(t *testing.T) {
+	ignored := ignoreCurrent()
+
+	// Mimic an Ingress that never becomes ready.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	tsURL, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL %q: %v", ts.URL, err)
+	}
+
+	prober := NewProber(
+		zaptest.NewLogger(t).Sugar(),
+		fakeProbeTargetLister{{
+			PodIPs:  sets.NewString(tsURL.Hostname()),
+			PodPort: tsURL.Port(),
+			URLs:    []*url.URL{tsURL},
+		}},
+		DefaultProbeVerifier,
+		func(*v1alpha1.Ingress) {},
+		WithInitialDelay(0),
+		WithProbeDeadline(50*time.Millisecond, nil),
+		WithStateTTL(50*time.Millisecond))
+
+	done := make(chan struct{})
+	cancelled := prober.Start(done)
+
+	for i := 0; i < 10; i++ {
+		ing := ingTemplate.DeepCopy()
+		ing.Name = "ingress-" + string(rune('a'+i))
+		if _, err := prober.IsReady(context.Background(), ing); err != nil {
+			t.Fatal("IsReady failed:", err)
+		}
+	}
+	if ingresses, podPorts := trackedStates(prober); ingresses != 10 || podPorts != 1 {
+		t.Errorf("Tracked states = (%d, %d), want: (10, 1)", ingresses, podPorts)
+	}
+
+	// The states fail, are never accessed again and get evicted.
+	if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		ingresses, podPorts := trackedStates(prober)
+		return ingresses == 0 && podPorts == 0, nil
+	}); err != nil {
+		ingresses, podPorts := trackedStates(prober)
+		t.Fatalf("Tracked states = (%d, %d), want: (0, 0)", ingresses, podPorts)
+	}
+
+	close(done)
+	<-cancelled
+	ts.Close()
+
+	// The goroutines probing the evicted states, the sweeper and the workers are gone.
+	goleak.VerifyNone(t, ignored...)
+}
+
+func TestCancelProbingTombstones(t *testing.T) {
+	ignored := ignoreCurrent()
+
+	ing := ingTemplate.DeepCopy()
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "gateway",
+		},
+		Status: v1.PodStatus{
+			PodIP: "198.51.100.1",
+		},
+	}
+	prober := NewProber(
+		zaptest.NewLogger(t).Sugar(),
+		fakeProbeTargetLister{{
+			PodIPs:  sets.NewString(pod.Status.PodIP),
+			PodPort: "8080",
+			URLs:    []*url.URL{{Scheme: "http", Host: "foo.bar.com"}},
+		}},
+		DefaultProbeVerifier,
+		func(*v1alpha1.Ingress) {},
+		// Never probe, the test only checks the states.
+		WithInitialDelay(time.Hour))
+
+	done := make(chan struct{})
+	cancelled := prober.Start(done)
+
+	if _, err := prober.IsReady(context.Background(), ing); err != nil {
+		t.Fatal("IsReady failed:", err)
+	}
+	if ingresses, podPorts := trackedStates(prober); ingresses != 1 || podPorts != 1 {
+		t.Errorf("Tracked states = (%d, %d), want: (1, 1)", ingresses, podPorts)
+	}
+
+	prober.CancelIngressProbing(cache.DeletedFinalStateUnknown{Key: "default/whatever", Obj: ing})
+	prober.CancelPodProbing(cache.DeletedFinalStateUnknown{Key: "default/gateway", Obj: pod})
+	if ingresses, podPorts := trackedStates(prober); ingresses != 0 || podPorts != 0 {
+		t.Errorf("Tracked states = (%d, %d), want: (0, 0)", ingresses, podPorts)
+	}
+
+	close(done)
+	<-cancelled
+	goleak.VerifyNone(t, ignored...)
+}
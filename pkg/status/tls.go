@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+)
+
+// tlsVerifier verifies the certificates served to HTTPS probes.
+type tlsVerifier struct {
+	// secretLister is used to compare the served certificates with the
+	// Secrets of the IngressTLS, nil to skip the comparison.
+	secretLister corev1listers.SecretLister
+}
+
+// verify checks that the certificate served for host in state covers host
+// and, if host is in the IngressTLS of ing, that it is the certificate of
+// the referenced Secret.
+func (v *tlsVerifier) verify(ing *v1alpha1.Ingress, host string, state *tls.ConnectionState) error {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return errors.New("no certificate was served")
+	}
+	leaf := state.PeerCertificates[0]
+	if err := leaf.VerifyHostname(host); err != nil {
+		return fmt.Errorf("the served certificate doesn't cover %q: %w", host, err)
+	}
+
+	if v.secretLister == nil {
+		return nil
+	}
+	ingressTLS := findIngressTLS(ing, host)
+	if ingressTLS == nil {
+		return nil
+	}
+	namespace := ingressTLS.SecretNamespace
+	if namespace == "" {
+		namespace = ing.Namespace
+	}
+	secret, err := v.secretLister.Secrets(namespace).Get(ingressTLS.SecretName)
+	if err != nil {
+		return fmt.Errorf("failed to get the Secret %s/%s: %w", namespace, ingressTLS.SecretName, err)
+	}
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return fmt.Errorf("the Secret %s/%s doesn't contain a PEM certificate", namespace, ingressTLS.SecretName)
+	}
+	if !bytes.Equal(block.Bytes, leaf.Raw) {
+		return fmt.Errorf("the served certificate for %q isn't the certificate of the Secret %s/%s",
+			host, namespace, ingressTLS.SecretName)
+	}
+	return nil
+}
+
+// findIngressTLS returns the IngressTLS of ing covering host, nil if there is none.
+func findIngressTLS(ing *v1alpha1.Ingress, host string) *v1alpha1.IngressTLS {
+	for i, t := range ing.Spec.TLS {
+		for _, h := range t.Hosts {
+			if h == host {
+				return &ing.Spec.TLS[i]
+			}
+		}
+	}
+	return nil
+}
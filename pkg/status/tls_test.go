@@ -0,0 +1,276 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zaptest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	network "knative.dev/networking/pkg"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/networking/pkg/ingress"
+)
+
+// The certificate of httptest TLS servers covers this host.
+const coveredHost = "example.com"
+
+func secretLister(t *testing.T, secrets ...*corev1.Secret) corev1listers.SecretLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, s := range secrets {
+		if err := indexer.Add(s); err != nil {
+			t.Fatal("Failed to add the Secret:", err)
+		}
+	}
+	return corev1listers.NewSecretLister(indexer)
+}
+
+func tlsSecret(namespace, name string, der []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		},
+	}
+}
+
+// otherCertificate returns a self-signed certificate for coveredHost that
+// isn't the certificate of the httptest TLS servers.
+func otherCertificate(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("Failed to generate a key:", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		DNSNames:     []string{coveredHost},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal("Failed to create a certificate:", err)
+	}
+	return der
+}
+
+func TestTLSVerifier(t *testing.T) {
+	ts := httptest.NewTLSServer(http.NotFoundHandler())
+	defer ts.Close()
+	state := &tls.ConnectionState{PeerCertificates: []*x509.Certificate{ts.Certificate()}}
+
+	ing := ingTemplate.DeepCopy()
+	ing.Spec.TLS = []v1alpha1.IngressTLS{{
+		Hosts:           []string{coveredHost},
+		SecretName:      "cert",
+		SecretNamespace: "istio-system",
+	}}
+	otherNamespace := ing.DeepCopy()
+	otherNamespace.Spec.TLS[0].SecretNamespace = ""
+
+	tests := []struct {
+		name     string
+		verifier *tlsVerifier
+		ing      *v1alpha1.Ingress
+		host     string
+		state    *tls.ConnectionState
+		wantErr  string
+	}{{
+		name:     "no certificate",
+		verifier: &tlsVerifier{},
+		ing:      ing,
+		host:     coveredHost,
+		state:    &tls.ConnectionState{},
+		wantErr:  "no certificate was served",
+	}, {
+		name:     "covered host",
+		verifier: &tlsVerifier{},
+		ing:      ing,
+		host:     coveredHost,
+		state:    state,
+	}, {
+		name:     "uncovered host",
+		verifier: &tlsVerifier{},
+		ing:      ing,
+		host:     "foo.bar.com",
+		state:    state,
+		wantErr:  `the served certificate doesn't cover "foo.bar.com"`,
+	}, {
+		name:     "matching secret",
+		verifier: &tlsVerifier{secretLister: secretLister(t, tlsSecret("istio-system", "cert", ts.Certificate().Raw))},
+		ing:      ing,
+		host:     coveredHost,
+		state:    state,
+	}, {
+		name:     "secret in the namespace of the Ingress",
+		verifier: &tlsVerifier{secretLister: secretLister(t, tlsSecret(ing.Namespace, "cert", ts.Certificate().Raw))},
+		ing:      otherNamespace,
+		host:     coveredHost,
+		state:    state,
+	}, {
+		name:     "rotated secret",
+		verifier: &tlsVerifier{secretLister: secretLister(t, tlsSecret("istio-system", "cert", otherCertificate(t)))},
+		ing:      ing,
+		host:     coveredHost,
+		state:    state,
+		wantErr:  "isn't the certificate of the Secret istio-system/cert",
+	}, {
+		name:     "missing secret",
+		verifier: &tlsVerifier{secretLister: secretLister(t)},
+		ing:      ing,
+		host:     coveredHost,
+		state:    state,
+		wantErr:  "failed to get the Secret istio-system/cert",
+	}, {
+		name: "secret without certificate",
+		verifier: &tlsVerifier{secretLister: secretLister(t, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "istio-system", Name: "cert"},
+		})},
+		ing:     ing,
+		host:    coveredHost,
+		state:   state,
+		wantErr: "doesn't contain a PEM certificate",
+	}, {
+		name:     "host without IngressTLS",
+		verifier: &tlsVerifier{secretLister: secretLister(t)},
+		ing:      ingTemplate,
+		host:     coveredHost,
+		state:    state,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.verifier.verify(test.ing, test.host, test.state)
+			if test.wantErr == "" && err != nil {
+				t.Error("verify() =", err)
+			} else if test.wantErr != "" && (err == nil || !strings.Contains(err.Error(), test.wantErr)) {
+				t.Errorf("verify() = %v, want an error containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestProbeTLSVerification(t *testing.T) {
+	tests := []struct {
+		name      string
+		host      string
+		opts      []ProberOption
+		wantReady bool
+	}{{
+		name:      "verification disabled",
+		host:      "foo.bar.com",
+		wantReady: true,
+	}, {
+		name:      "covered host",
+		host:      coveredHost,
+		opts:      []ProberOption{WithTLSVerification(nil)},
+		wantReady: true,
+	}, {
+		name: "uncovered host",
+		host: "foo.bar.com",
+		opts: []ProberOption{WithTLSVerification(nil)},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ing := ingTemplate.DeepCopy()
+			ing.Spec.Rules[0].Hosts = []string{test.host}
+			hash, err := ingress.InsertProbe(ing.DeepCopy())
+			if err != nil {
+				t.Fatal("Failed to insert probe:", err)
+			}
+
+			serverNames := make(chan string, 100)
+			ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				serverNames <- r.TLS.ServerName
+				w.Header().Set(network.HashHeaderName, hash)
+				w.WriteHeader(http.StatusOK)
+			}))
+			ts.StartTLS()
+			defer ts.Close()
+			tsURL, err := url.Parse(ts.URL)
+			if err != nil {
+				t.Fatalf("Failed to parse URL %q: %v", ts.URL, err)
+			}
+
+			ready := make(chan *v1alpha1.Ingress)
+			prober := NewProber(
+				zaptest.NewLogger(t).Sugar(),
+				fakeProbeTargetLister{{
+					PodIPs:  sets.NewString(tsURL.Hostname()),
+					PodPort: tsURL.Port(),
+					URLs:    []*url.URL{tsURL},
+				}},
+				DefaultProbeVerifier,
+				func(ing *v1alpha1.Ingress) {
+					ready <- ing
+				},
+				append(test.opts, WithInitialDelay(0))...)
+
+			done := make(chan struct{})
+			cancelled := prober.Start(done)
+			defer func() {
+				close(done)
+				<-cancelled
+			}()
+
+			if _, err := prober.IsReady(context.Background(), ing); err != nil {
+				t.Fatal("IsReady failed:", err)
+			}
+
+			select {
+			case serverName := <-serverNames:
+				if serverName != test.host {
+					t.Errorf("SNI = %q, want: %q", serverName, test.host)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Timed out waiting for a probe request.")
+			}
+
+			select {
+			case <-ready:
+				if !test.wantReady {
+					t.Fatal("Probing succeeded while it should not have succeeded")
+				}
+			case <-time.After(time.Second):
+				if test.wantReady {
+					t.Fatal("Timed out waiting for probing to succeed.")
+				}
+			}
+		})
+	}
+}
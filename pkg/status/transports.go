@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"net/http"
+	"sync"
+
+	"knative.dev/networking/pkg/apis/networking"
+)
+
+// transportKey identifies the transport shared by the probes of a Pod port.
+type transportKey struct {
+	addr     podAddress
+	protocol networking.ProtocolType
+}
+
+// transportPool shares a transport, and thus its connections, between the
+// probes of each Pod port and protocol.
+type transportPool struct {
+	factory TransportFactory
+
+	mu         sync.Mutex
+	transports map[transportKey]http.RoundTripper
+}
+
+func newTransportPool(factory TransportFactory) *transportPool {
+	return &transportPool{
+		factory:    factory,
+		transports: make(map[transportKey]http.RoundTripper),
+	}
+}
+
+// get returns the transport of the Pod port and protocol, creating it if needed.
+func (p *transportPool) get(addr podAddress, protocol networking.ProtocolType) http.RoundTripper {
+	key := transportKey{addr: addr, protocol: protocol}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	transport, ok := p.transports[key]
+	if !ok {
+		transport = p.factory(addr.ip, addr.port, protocol)
+		p.transports[key] = transport
+	}
+	return transport
+}
+
+// dispose closes the idle connections of the transports of the Pod port and
+// forgets them.  The connections in use are closed once they become idle and
+// time out.
+func (p *transportPool) dispose(addr podAddress) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, transport := range p.transports {
+		if key.addr == addr {
+			closeIdleConnections(transport)
+			delete(p.transports, key)
+		}
+	}
+}
+
+// disposeAll disposes of all the transports.
+func (p *transportPool) disposeAll() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, transport := range p.transports {
+		closeIdleConnections(transport)
+		delete(p.transports, key)
+	}
+}
+
+// len returns the number of pooled transports.
+func (p *transportPool) len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.transports)
+}
+
+func closeIdleConnections(transport http.RoundTripper) {
+	if t, ok := transport.(interface{ CloseIdleConnections() }); ok {
+		t.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/time/rate"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	network "knative.dev/networking/pkg"
+	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/networking/pkg/ingress"
+)
+
+type fakeTransport struct {
+	http.RoundTripper
+	closed int32
+}
+
+func (t *fakeTransport) CloseIdleConnections() {
+	atomic.AddInt32(&t.closed, 1)
+}
+
+func TestTransportPool(t *testing.T) {
+	var created int32
+	pool := newTransportPool(func(podIP, podPort string, protocol networking.ProtocolType) http.RoundTripper {
+		atomic.AddInt32(&created, 1)
+		return &fakeTransport{}
+	})
+	addr1 := podAddress{ip: "10.0.0.1", port: "8080"}
+	addr2 := podAddress{ip: "10.0.0.1", port: "8443"}
+
+	t1 := pool.get(addr1, networking.ProtocolHTTP1)
+	if got := pool.get(addr1, networking.ProtocolHTTP1); got != t1 {
+		t.Error("The transport of the same Pod port and protocol isn't shared")
+	}
+	if got := pool.get(addr1, networking.ProtocolH2C); got == t1 {
+		t.Error("The transport of another protocol is shared")
+	}
+	t2 := pool.get(addr2, networking.ProtocolHTTP1)
+	if t2 == t1 {
+		t.Error("The transport of another Pod port is shared")
+	}
+	if got, want := atomic.LoadInt32(&created), int32(3); got != want {
+		t.Errorf("Created transports = %d, want: %d", got, want)
+	}
+
+	pool.dispose(addr1)
+	if got, want := pool.len(), 1; got != want {
+		t.Errorf("Pooled transports = %d, want: %d", got, want)
+	}
+	if got := atomic.LoadInt32(&t1.(*fakeTransport).closed); got != 1 {
+		t.Errorf("Idle connections of the disposed transport closed %d times, want: 1", got)
+	}
+	if got := atomic.LoadInt32(&t2.(*fakeTransport).closed); got != 0 {
+		t.Errorf("Idle connections of the other transport closed %d times, want: 0", got)
+	}
+	if got := pool.get(addr1, networking.ProtocolHTTP1); got == t1 {
+		t.Error("The disposed transport is reused")
+	}
+
+	pool.disposeAll()
+	if got := pool.len(); got != 0 {
+		t.Errorf("Pooled transports = %d, want: 0", got)
+	}
+}
+
+// connCountingServer returns a server answering all the probes with an HTTP
+// 200, and the number of connections it accepted.
+func connCountingServer() (*httptest.Server, *int64) {
+	var conns int64
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	ts.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt64(&conns, 1)
+		}
+	}
+	ts.Start()
+	return ts, &conns
+}
+
+// probeIngresses probes count Ingresses with distinct hosts served by ts and
+// waits until they are all ready.
+func probeIngresses(ctx context.Context, logger *zap.SugaredLogger, ts *httptest.Server, count int, opts ...ProberOption) (*Prober, error) {
+	tsURL, err := url.Parse(ts.URL)
+	if err != nil {
+		return nil, err
+	}
+	ready := make(chan struct{}, count)
+	prober := NewProber(
+		logger,
+		fakeProbeTargetLister{{
+			PodIPs:  sets.NewString(tsURL.Hostname()),
+			PodPort: tsURL.Port(),
+			URLs:    []*url.URL{tsURL},
+		}},
+		// Any HTTP 200 shows that the Ingress is ready.
+		ProbeVerifierFunc(func(context.Context, string, *http.Response, []byte) (bool, error) {
+			return true, nil
+		}),
+		func(*v1alpha1.Ingress) {
+			ready <- struct{}{}
+		},
+		append([]ProberOption{
+			WithInitialDelay(0),
+			WithRateLimit(rate.Inf, 1),
+		}, opts...)...)
+
+	done := make(chan struct{})
+	cancelled := prober.Start(done)
+	defer func() {
+		close(done)
+		<-cancelled
+	}()
+
+	for i := 0; i < count; i++ {
+		ing := ingTemplate.DeepCopy()
+		ing.Name = fmt.Sprint("ingress-", i)
+		ing.Spec.Rules[0].Hosts = []string{fmt.Sprintf("ingress-%d.example.com", i)}
+		if _, err := prober.IsReady(ctx, ing); err != nil {
+			return nil, err
+		}
+	}
+	for i := 0; i < count; i++ {
+		select {
+		case <-ready:
+		case <-time.After(time.Minute):
+			return nil, fmt.Errorf("timed out waiting for %d Ingresses to be ready", count-i)
+		}
+	}
+	return prober, nil
+}
+
+func TestProbeConnectionReuse(t *testing.T) {
+	ts, conns := connCountingServer()
+	defer ts.Close()
+
+	// A single worker probes the Ingresses one after the other on the same connection.
+	if _, err := probeIngresses(context.Background(), zaptest.NewLogger(t).Sugar(), ts, 20, WithWorkers(1)); err != nil {
+		t.Fatal("Probing failed:", err)
+	}
+	if got := atomic.LoadInt64(conns); got != 1 {
+		t.Errorf("Connections = %d, want: 1", got)
+	}
+}
+
+func TestTransportDisposal(t *testing.T) {
+	ing := ingTemplate.DeepCopy()
+	hash, err := ingress.InsertProbe(ing.DeepCopy())
+	if err != nil {
+		t.Fatal("Failed to insert probe:", err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(network.HashHeaderName, hash)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	tsURL, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL %q: %v", ts.URL, err)
+	}
+
+	ready := make(chan *v1alpha1.Ingress, 1)
+	prober := NewProber(
+		zaptest.NewLogger(t).Sugar(),
+		fakeProbeTargetLister{{
+			PodIPs:  sets.NewString(tsURL.Hostname()),
+			PodPort: tsURL.Port(),
+			URLs:    []*url.URL{tsURL},
+		}},
+		DefaultProbeVerifier,
+		func(ing *v1alpha1.Ingress) {
+			ready <- ing
+		},
+		WithInitialDelay(0))
+
+	done := make(chan struct{})
+	cancelled := prober.Start(done)
+	defer func() {
+		close(done)
+		<-cancelled
+	}()
+
+	if _, err := prober.IsReady(context.Background(), ing); err != nil {
+		t.Fatal("IsReady failed:", err)
+	}
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for probing to succeed.")
+	}
+	if got := prober.transports.len(); got != 1 {
+		t.Errorf("Pooled transports = %d, want: 1", got)
+	}
+
+	// The transport of the Pod is disposed of with its context.
+	prober.CancelPodProbing(&v1.Pod{Status: v1.PodStatus{PodIP: tsURL.Hostname()}})
+	if got := prober.transports.len(); got != 0 {
+		t.Errorf("Pooled transports = %d, want: 0", got)
+	}
+}
+
+func TestTransportDisposalQueued(t *testing.T) {
+	ing := ingTemplate.DeepCopy()
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+	tsURL, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL %q: %v", ts.URL, err)
+	}
+
+	// The workers aren't started, so that the work items stay queued.
+	prober := NewProber(
+		zaptest.NewLogger(t).Sugar(),
+		fakeProbeTargetLister{{
+			PodIPs:  sets.NewString(tsURL.Hostname()),
+			PodPort: tsURL.Port(),
+			URLs:    []*url.URL{tsURL},
+		}},
+		DefaultProbeVerifier,
+		func(*v1alpha1.Ingress) {},
+		WithInitialDelay(0))
+	defer prober.workQueue.ShutDown()
+
+	if _, err := prober.IsReady(context.Background(), ing); err != nil {
+		t.Fatal("IsReady failed:", err)
+	}
+	queued := prober.workQueue.Len()
+	if queued == 0 {
+		t.Fatal("No work item queued")
+	}
+
+	// The queued work items of the cancelled Pod don't create transports.
+	prober.CancelPodProbing(&v1.Pod{Status: v1.PodStatus{PodIP: tsURL.Hostname()}})
+	for i := 0; i < queued; i++ {
+		prober.processWorkItem()
+	}
+	if got := prober.transports.len(); got != 0 {
+		t.Errorf("Pooled transports = %d, want: 0", got)
+	}
+}
+
+// unpooledTransport creates a new transport for every probe, as the prober
+// used to.  Keep-alives are disabled so that the connections left idle by the
+// discarded transports don't exhaust the file descriptors.
+type unpooledTransport struct {
+	factory func() http.RoundTripper
+}
+
+func (t unpooledTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	transport := t.factory()
+	if transport, ok := transport.(*http.Transport); ok {
+		transport.DisableKeepAlives = true
+	}
+	return transport.RoundTrip(r)
+}
+
+func BenchmarkProbeConnections(b *testing.B) {
+	const ingresses = 10000
+
+	tests := []struct {
+		name string
+		opts func(*Prober) []ProberOption
+	}{{
+		name: "pooled",
+		opts: func(*Prober) []ProberOption { return nil },
+	}, {
+		name: "per-probe transport",
+		opts: func(m *Prober) []ProberOption {
+			return []ProberOption{WithTransportFactory(func(podIP, podPort string, protocol networking.ProtocolType) http.RoundTripper {
+				return unpooledTransport{factory: func() http.RoundTripper {
+					return m.newTransport(podIP, podPort, protocol)
+				}}
+			})}
+		},
+	}}
+
+	for _, test := range tests {
+		b.Run(test.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ts, conns := connCountingServer()
+				// The default transports of this prober are used to build the unpooled ones.
+				defaults := NewProber(zap.NewNop().Sugar(), fakeProbeTargetLister{}, nil, nil)
+				if _, err := probeIngresses(context.Background(), zap.NewNop().Sugar(), ts, ingresses,
+					test.opts(defaults)...); err != nil {
+					b.Fatal("Probing failed:", err)
+				}
+				b.ReportMetric(float64(atomic.LoadInt64(conns)), "conns/op")
+				ts.Close()
+			}
+		})
+	}
+}
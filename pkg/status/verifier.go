@@ -0,0 +1,111 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	network "knative.dev/networking/pkg"
+	"knative.dev/pkg/logging"
+)
+
+// ProbeVerifier decides whether the response to a probe shows that an
+// Ingress has been programmed.
+type ProbeVerifier interface {
+	// Verify returns true if the response r, with the given body, shows that
+	// the version of the Ingress with the given hash is served, and false with
+	// an error if the Ingress should be probed again later.  The context
+	// carries a logger describing the probe.
+	Verify(ctx context.Context, hash string, r *http.Response, body []byte) (bool, error)
+}
+
+// ProbeVerifierFunc is an adapter to use ordinary functions as ProbeVerifiers.
+type ProbeVerifierFunc func(ctx context.Context, hash string, r *http.Response, body []byte) (bool, error)
+
+// Verify implements ProbeVerifier.
+func (f ProbeVerifierFunc) Verify(ctx context.Context, hash string, r *http.Response, body []byte) (bool, error) {
+	return f(ctx, hash, r, body)
+}
+
+var (
+	// DefaultProbeVerifier succeeds on an HTTP 200 whose K-Network-Hash header
+	// matches the hash, or is missing, and retries on a mismatching hash, an
+	// HTTP 404 or an HTTP 503.  Nothing can be learned from any other status,
+	// so it is assumed to be a success.
+	DefaultProbeVerifier ProbeVerifier = ProbeVerifierFunc(verifyDefault)
+
+	// StrictProbeVerifier only succeeds on an HTTP 200 whose K-Network-Hash
+	// header matches the hash, and retries on any other response.
+	StrictProbeVerifier ProbeVerifier = ProbeVerifierFunc(verifyStrict)
+
+	// BodyHashProbeVerifier only succeeds on an HTTP 200 whose body, stripped
+	// of surrounding whitespace, is the hash, and retries on any other
+	// response.  It suits Ingress implementations that can't return the
+	// K-Network-Hash header of the probe request.
+	BodyHashProbeVerifier ProbeVerifier = ProbeVerifierFunc(verifyBodyHash)
+)
+
+func verifyDefault(ctx context.Context, hash string, r *http.Response, _ []byte) (bool, error) {
+	// In the happy path, the probe request is forwarded to Activator or Queue-Proxy and the response (HTTP 200)
+	// contains the "K-Network-Hash" header that can be compared with the expected hash. If the hashes match,
+	// probing is successful, if they don't match, a new probe will be sent later.
+	// An HTTP 404/503 is expected in the case of the creation of a new Knative service because the rules will
+	// not be present in the Envoy config until the new VirtualService is applied.
+	// No information can be extracted from any other scenario (e.g. HTTP 302), therefore in that case,
+	// probing is assumed to be successful because it is better to say that an Ingress is Ready before it
+	// actually is Ready than never marking it as Ready. It is best effort.
+	switch r.StatusCode {
+	case http.StatusOK:
+		if r.Header.Get(network.HashHeaderName) == "" {
+			logging.FromContext(ctx).Errorf("Probing abandoned: the response doesn't contain the %q header",
+				network.HashHeaderName)
+			return true, nil
+		}
+		return verifyStrict(ctx, hash, r, nil)
+
+	case http.StatusNotFound, http.StatusServiceUnavailable:
+		return false, fmt.Errorf("unexpected status code: want %v, got %v", http.StatusOK, r.StatusCode)
+
+	default:
+		logging.FromContext(ctx).Errorf("Probing abandoned: the response status is %v, expected one of: %v",
+			r.StatusCode, []int{http.StatusOK, http.StatusNotFound, http.StatusServiceUnavailable})
+		return true, nil
+	}
+}
+
+func verifyStrict(_ context.Context, hash string, r *http.Response, _ []byte) (bool, error) {
+	if r.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code: want %v, got %v", http.StatusOK, r.StatusCode)
+	}
+	if got := r.Header.Get(network.HashHeaderName); got != hash {
+		return false, fmt.Errorf("unexpected hash: want %q, got %q", hash, got)
+	}
+	return true, nil
+}
+
+func verifyBodyHash(_ context.Context, hash string, r *http.Response, body []byte) (bool, error) {
+	if r.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code: want %v, got %v", http.StatusOK, r.StatusCode)
+	}
+	if got := strings.TrimSpace(string(body)); got != hash {
+		return false, fmt.Errorf("unexpected hash: want %q, got %q", hash, got)
+	}
+	return true, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2020 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"net/http"
+	"testing"
+
+	network "knative.dev/networking/pkg"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestProbeVerifiers(t *testing.T) {
+	const hash = "Hi! I am hash!"
+	cases := []struct {
+		name       string
+		resp       *http.Response
+		body       string
+		wantDef    bool
+		wantStrict bool
+		wantBody   bool
+	}{{
+		name: "HTTP 200 matching hash",
+		resp: &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{network.HashHeaderName: []string{hash}},
+		},
+		wantDef:    true,
+		wantStrict: true,
+	}, {
+		name: "HTTP 200 mismatching hash",
+		resp: &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{network.HashHeaderName: []string{"nope"}},
+		},
+	}, {
+		name: "HTTP 200 missing header",
+		resp: &http.Response{
+			StatusCode: http.StatusOK,
+		},
+		wantDef: true,
+	}, {
+		name: "HTTP 200 matching body",
+		resp: &http.Response{
+			StatusCode: http.StatusOK,
+		},
+		body:     hash + "\n",
+		wantDef:  true,
+		wantBody: true,
+	}, {
+		name: "HTTP 200 mismatching body",
+		resp: &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{network.HashHeaderName: []string{hash}},
+		},
+		body:       "nope",
+		wantDef:    true,
+		wantStrict: true,
+	}, {
+		name: "HTTP 404",
+		resp: &http.Response{
+			StatusCode: http.StatusNotFound,
+		},
+	}, {
+		name: "HTTP 503",
+		resp: &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+		},
+	}, {
+		name: "HTTP 403",
+		resp: &http.Response{
+			StatusCode: http.StatusForbidden,
+		},
+		wantDef: true,
+	}, {
+		name: "HTTP 302",
+		resp: &http.Response{
+			StatusCode: http.StatusFound,
+			Header:     http.Header{network.HashHeaderName: []string{hash}},
+		},
+		body:    hash,
+		wantDef: true,
+	}}
+
+	ctx := logtesting.TestContextWithLogger(t)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			for _, v := range []struct {
+				name     string
+				verifier ProbeVerifier
+				want     bool
+			}{
+				{"default", DefaultProbeVerifier, c.wantDef},
+				{"strict", StrictProbeVerifier, c.wantStrict},
+				{"body hash", BodyHashProbeVerifier, c.wantBody},
+			} {
+				got, err := v.verifier.Verify(ctx, hash, c.resp, []byte(c.body))
+				if got != v.want {
+					t.Errorf("%s verifier got: %v, want: %v", v.name, got, v.want)
+				}
+				if !got && err == nil {
+					t.Errorf("%s verifier returned false without an error", v.name)
+				}
+			}
+		})
+	}
+}
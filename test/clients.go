@@ -43,6 +43,7 @@ type NetworkingClients struct {
 	ServerlessServices networkingv1alpha1.ServerlessServiceInterface
 	Ingresses          networkingv1alpha1.IngressInterface
 	Certificates       networkingv1alpha1.CertificateInterface
+	IngressClasses     networkingv1alpha1.IngressClassInterface
 }
 
 // NewClients instantiates and returns several clientsets required for making request to the
@@ -95,6 +96,7 @@ func newNetworkingClients(cfg *rest.Config, namespace string) (*NetworkingClient
 		ServerlessServices: cs.NetworkingV1alpha1().ServerlessServices(namespace),
 		Ingresses:          cs.NetworkingV1alpha1().Ingresses(namespace),
 		Certificates:       cs.NetworkingV1alpha1().Certificates(namespace),
+		IngressClasses:     cs.NetworkingV1alpha1().IngressClasses(),
 	}, nil
 }
 
@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/networking/test"
+	"knative.dev/networking/test/test_images/runtime/handlers"
+)
+
+// TestCompression verifies that an Ingress compresses large responses of the
+// configured content types when the client accepts it.
+func TestCompression(t *testing.T) {
+	t.Parallel()
+	clients := test.Setup(t)
+
+	name, port, _ := CreateRuntimeService(t, clients, networking.ServicePortNameHTTP1)
+
+	const minimumSize = 1024
+
+	// Create a simple Ingress over the Service, compressing text/plain.
+	_, client, _ := CreateIngressReady(t, clients, v1alpha1.IngressSpec{
+		Rules: []v1alpha1.IngressRule{{
+			Hosts:      []string{name + ".example.com"},
+			Visibility: v1alpha1.IngressVisibilityExternalIP,
+			HTTP: &v1alpha1.HTTPIngressRuleValue{
+				Paths: []v1alpha1.HTTPIngressPath{{
+					Splits: []v1alpha1.IngressBackendSplit{{
+						IngressBackend: v1alpha1.IngressBackend{
+							ServiceName:      name,
+							ServiceNamespace: test.ServingNamespace,
+							ServicePort:      intstr.FromInt(port),
+						},
+					}},
+					Compression: &v1alpha1.Compression{
+						Algorithms:   []v1alpha1.CompressionAlgorithm{v1alpha1.CompressionAlgorithmGzip},
+						MinimumSize:  minimumSize,
+						ContentTypes: []string{"text/plain"},
+					},
+				}},
+			},
+		}},
+	})
+
+	large := "http://" + name + ".example.com" + handlers.LargePath + "?bytes=" + strconv.Itoa(100*minimumSize)
+	small := "http://" + name + ".example.com" + handlers.LargePath + "?bytes=" + strconv.Itoa(minimumSize/2)
+	largeOctetStream := large + "&contentType=" + url.QueryEscape("application/octet-stream")
+
+	tests := []struct {
+		name           string
+		url            string
+		acceptEncoding string
+		want           string
+	}{{
+		name:           "large response",
+		url:            large,
+		acceptEncoding: "gzip",
+		want:           "gzip",
+	}, {
+		name:           "client does not accept compression",
+		url:            large,
+		acceptEncoding: "identity",
+	}, {
+		name:           "client does not accept the algorithm",
+		url:            large,
+		acceptEncoding: "br",
+	}, {
+		name:           "small response",
+		url:            small,
+		acceptEncoding: "gzip",
+	}, {
+		name:           "content type not allowed",
+		url:            largeOctetStream,
+		acceptEncoding: "gzip",
+	}}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			if err != nil {
+				t.Fatal("Error creating Request:", err)
+			}
+			// Setting Accept-Encoding explicitly stops the transport from
+			// transparently decompressing the response.
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal("Error making GET request:", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Got unexpected status code %d, wanted %d", resp.StatusCode, http.StatusOK)
+				DumpResponse(t, resp)
+				return
+			}
+			if got := resp.Header.Get("Content-Encoding"); got != tt.want {
+				t.Errorf("Content-Encoding = %q, wanted %q", got, tt.want)
+			}
+		})
+	}
+}
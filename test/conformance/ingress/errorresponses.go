@@ -0,0 +1,167 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/networking/test"
+	"knative.dev/pkg/reconciler"
+)
+
+// TestErrorResponses verifies that an Ingress replaces the error responses
+// for unavailable backends with the configured bodies and status codes.
+func TestErrorResponses(t *testing.T) {
+	t.Parallel()
+	clients := test.Setup(t)
+
+	name, port, _ := CreateRuntimeService(t, clients, networking.ServicePortNameHTTP1)
+	failingName, failingPort, _ := CreateFailingService(t, clients)
+
+	const (
+		key  = "unavailable.html"
+		page = "<html><body>We'll be right back.</body></html>"
+	)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: test.ServingNamespace,
+		},
+		Data: map[string]string{
+			key: page,
+		},
+	}
+	t.Cleanup(func() {
+		clients.KubeClient.Kube.CoreV1().ConfigMaps(cm.Namespace).Delete(cm.Name, &metav1.DeleteOptions{})
+	})
+	if err := reconciler.RetryTestErrors(func(attempts int) error {
+		_, err := clients.KubeClient.Kube.CoreV1().ConfigMaps(cm.Namespace).Create(cm)
+		return err
+	}); err != nil {
+		t.Fatal("Error creating ConfigMap:", err)
+	}
+
+	// The failing Service never has ready endpoints, so requests for /failing
+	// result in an error generated by the Ingress, while the rest of the
+	// requests (including probes) reach the runtime Service.
+	rule := func(host string, statusCode int) v1alpha1.IngressRule {
+		return v1alpha1.IngressRule{
+			Hosts:      []string{host},
+			Visibility: v1alpha1.IngressVisibilityExternalIP,
+			HTTP: &v1alpha1.HTTPIngressRuleValue{
+				Paths: []v1alpha1.HTTPIngressPath{{
+					Path: "/failing",
+					Splits: []v1alpha1.IngressBackendSplit{{
+						IngressBackend: v1alpha1.IngressBackend{
+							ServiceName:      failingName,
+							ServiceNamespace: test.ServingNamespace,
+							ServicePort:      intstr.FromInt(failingPort),
+						},
+					}},
+				}, {
+					Splits: []v1alpha1.IngressBackendSplit{{
+						IngressBackend: v1alpha1.IngressBackend{
+							ServiceName:      name,
+							ServiceNamespace: test.ServingNamespace,
+							ServicePort:      intstr.FromInt(port),
+						},
+					}},
+				}},
+			},
+			ErrorResponses: []v1alpha1.ErrorResponse{{
+				StatusCodes: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+				Body: v1alpha1.ConfigMapKeyReference{
+					ConfigMapName:      cm.Name,
+					ConfigMapNamespace: cm.Namespace,
+					Key:                key,
+				},
+				ContentType: "text/html",
+				StatusCode:  statusCode,
+			}},
+		}
+	}
+
+	keepHost := name + "-keep.example.com"
+	replaceHost := name + "-replace.example.com"
+	_, client, _ := CreateIngressReady(t, clients, v1alpha1.IngressSpec{
+		Rules: []v1alpha1.IngressRule{
+			rule(keepHost, 0),
+			rule(replaceHost, http.StatusInternalServerError),
+		},
+	})
+
+	tests := []struct {
+		name     string
+		url      string
+		wantCode int
+		wantBody string
+	}{{
+		name:     "keeps status code",
+		url:      "http://" + keepHost + "/failing",
+		wantCode: http.StatusServiceUnavailable,
+		wantBody: page,
+	}, {
+		name:     "replaces status code",
+		url:      "http://" + replaceHost + "/failing",
+		wantCode: http.StatusInternalServerError,
+		wantBody: page,
+	}, {
+		name:     "successful response",
+		url:      "http://" + keepHost,
+		wantCode: http.StatusOK,
+	}}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp, err := client.Get(tt.url)
+			if err != nil {
+				t.Fatal("Error making GET request:", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("Got unexpected status code %d, wanted %d", resp.StatusCode, tt.wantCode)
+				DumpResponse(t, resp)
+				return
+			}
+			if tt.wantBody == "" {
+				return
+			}
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal("Error reading response body:", err)
+			}
+			if got := string(b); got != tt.wantBody {
+				t.Errorf("Body = %q, wanted %q", got, tt.wantBody)
+			}
+			if got, want := resp.Header.Get("Content-Type"), "text/html"; got != want {
+				t.Errorf("Content-Type = %q, wanted %q", got, want)
+			}
+		})
+	}
+}
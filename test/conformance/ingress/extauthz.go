@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/networking/test"
+)
+
+// TestExtAuthz verifies that an Ingress consults the external authorization
+// service before forwarding a request, and forwards the configured headers.
+func TestExtAuthz(t *testing.T) {
+	t.Parallel()
+	clients := test.Setup(t)
+
+	name, port, _ := CreateRuntimeService(t, clients, networking.ServicePortNameHTTP1)
+	authzName, authzPort, _ := CreateAuthzService(t, clients)
+
+	const (
+		// These match the headers used by the authz test image.
+		decisionHeader = "X-Authz-Decision"
+		userHeader     = "X-Authz-User"
+	)
+
+	// Create a simple Ingress over the Service, guarded by the authz service.
+	_, client, _ := CreateIngressReady(t, clients, v1alpha1.IngressSpec{
+		Rules: []v1alpha1.IngressRule{{
+			Hosts:      []string{name + ".example.com"},
+			Visibility: v1alpha1.IngressVisibilityExternalIP,
+			HTTP: &v1alpha1.HTTPIngressRuleValue{
+				Paths: []v1alpha1.HTTPIngressPath{{
+					Splits: []v1alpha1.IngressBackendSplit{{
+						IngressBackend: v1alpha1.IngressBackend{
+							ServiceName:      name,
+							ServiceNamespace: test.ServingNamespace,
+							ServicePort:      intstr.FromInt(port),
+						},
+					}},
+					ExtAuthz: &v1alpha1.ExtAuthz{
+						Service: v1alpha1.IngressBackend{
+							ServiceName:      authzName,
+							ServiceNamespace: test.ServingNamespace,
+							ServicePort:      intstr.FromInt(authzPort),
+						},
+						RequestHeaders:  []string{decisionHeader},
+						UpstreamHeaders: []string{userHeader},
+						Timeout:         &metav1.Duration{Duration: 5 * time.Second},
+					},
+				}},
+			},
+		}},
+	})
+
+	tests := []struct {
+		name     string
+		decision string
+		want     int
+	}{{
+		name: "no decision",
+		want: http.StatusForbidden,
+	}, {
+		name:     "denied",
+		decision: "deny",
+		want:     http.StatusForbidden,
+	}, {
+		name:     "allowed",
+		decision: "allow",
+		want:     http.StatusOK,
+	}}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ri := RuntimeRequestWithExpectations(t, client, "http://"+name+".example.com",
+				[]ResponseExpectation{StatusCodeExpectation(sets.NewInt(tt.want))},
+				false,
+				func(r *http.Request) {
+					if tt.decision != "" {
+						r.Header.Set(decisionHeader, tt.decision)
+					}
+				})
+			if tt.want != http.StatusOK {
+				return
+			}
+			if ri == nil {
+				t.Error("Couldn't make request")
+				return
+			}
+			if got, want := ri.Request.Headers.Get(userHeader), "conformance"; got != want {
+				t.Errorf("Header[%q] = %q, wanted %q", userHeader, got, want)
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"google.golang.org/grpc"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/networking/pkg/apis/networking"
 	"knative.dev/networking/pkg/apis/networking/v1alpha1"
 	"knative.dev/networking/test"
 	ping "knative.dev/networking/test/test_images/grpc-ping/proto"
@@ -89,6 +90,64 @@ func TestGRPC(t *testing.T) {
 	}
 }
 
+// TestGRPCProtocol verifies that GRPC may be used via an Ingress whose backend
+// declares its protocol explicitly, rather than relying on the port name.
+func TestGRPCProtocol(t *testing.T) {
+	t.Parallel()
+	clients := test.Setup(t)
+
+	const suffix = "- pong"
+	// Deliberately not named http2, so the port name does not hint at the protocol.
+	name, port, _ := createGRPCService(t, clients, suffix, "grpc")
+
+	domain := name + ".example.com"
+
+	// Create a simple Ingress over the Service.
+	_, dialCtx, _ := CreateIngressReadyDialContext(t, clients, v1alpha1.IngressSpec{
+		Rules: []v1alpha1.IngressRule{{
+			Hosts:      []string{domain},
+			Visibility: v1alpha1.IngressVisibilityExternalIP,
+			HTTP: &v1alpha1.HTTPIngressRuleValue{
+				Paths: []v1alpha1.HTTPIngressPath{{
+					Splits: []v1alpha1.IngressBackendSplit{{
+						IngressBackend: v1alpha1.IngressBackend{
+							ServiceName:      name,
+							ServiceNamespace: test.ServingNamespace,
+							ServicePort:      intstr.FromInt(port),
+							Protocol:         networking.ProtocolGRPC,
+						},
+					}},
+				}},
+			},
+		}},
+	})
+
+	conn, err := grpc.Dial(
+		domain+":80",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return dialCtx(ctx, "unused", addr)
+		}),
+	)
+	if err != nil {
+		t.Fatal("Dial() =", err)
+	}
+	defer conn.Close()
+	pc := ping.NewPingServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	stream, err := pc.PingStream(ctx)
+	if err != nil {
+		t.Fatal("PingStream() =", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		checkGRPCRoundTrip(t, stream, suffix)
+	}
+}
+
 // TestGRPCSplit verifies that websockets may be used across a traffic split.
 func TestGRPCSplit(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,218 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"crypto"
+	cryptorand "crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/networking/test"
+)
+
+// TestJWT verifies that an Ingress only forwards requests carrying a valid
+// JSON Web Token, and that the configured claims reach the backend as headers.
+func TestJWT(t *testing.T) {
+	t.Parallel()
+	clients := test.Setup(t)
+
+	name, port, _ := CreateRuntimeService(t, clients, networking.ServicePortNameHTTP1)
+
+	const (
+		issuer      = "https://issuer.example.com"
+		audience    = "conformance"
+		subject     = "knative-user"
+		claimHeader = "X-Jwt-Subject"
+	)
+
+	key := generateRSAKey(t)
+	otherKey := generateRSAKey(t)
+
+	// Create a simple Ingress over the Service, authenticated with JWT.
+	_, client, _ := CreateIngressReady(t, clients, v1alpha1.IngressSpec{
+		Rules: []v1alpha1.IngressRule{{
+			Hosts:      []string{name + ".example.com"},
+			Visibility: v1alpha1.IngressVisibilityExternalIP,
+			HTTP: &v1alpha1.HTTPIngressRuleValue{
+				Paths: []v1alpha1.HTTPIngressPath{{
+					Splits: []v1alpha1.IngressBackendSplit{{
+						IngressBackend: v1alpha1.IngressBackend{
+							ServiceName:      name,
+							ServiceNamespace: test.ServingNamespace,
+							ServicePort:      intstr.FromInt(port),
+						},
+					}},
+					JWT: &v1alpha1.JWTAuthentication{
+						Issuers:   []string{issuer},
+						Audiences: []string{audience},
+						JWKS: v1alpha1.JWKSSource{
+							Inline: jwksFor(t, &key.PublicKey),
+						},
+						ForwardClaims: map[string]string{
+							"sub": claimHeader,
+						},
+					},
+				}},
+			},
+		}},
+	})
+
+	validClaims := func() map[string]interface{} {
+		return map[string]interface{}{
+			"iss": issuer,
+			"aud": audience,
+			"sub": subject,
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  int
+	}{{
+		name: "no token",
+		want: http.StatusUnauthorized,
+	}, {
+		name:  "malformed token",
+		token: "not-a-token",
+		want:  http.StatusUnauthorized,
+	}, {
+		name:  "signed by unknown key",
+		token: signJWT(t, otherKey, validClaims()),
+		want:  http.StatusUnauthorized,
+	}, {
+		name: "unknown issuer",
+		token: func() string {
+			claims := validClaims()
+			claims["iss"] = "https://evil.example.com"
+			return signJWT(t, key, claims)
+		}(),
+		want: http.StatusUnauthorized,
+	}, {
+		name: "unknown audience",
+		token: func() string {
+			claims := validClaims()
+			claims["aud"] = "someone-else"
+			return signJWT(t, key, claims)
+		}(),
+		want: http.StatusUnauthorized,
+	}, {
+		name: "expired",
+		token: func() string {
+			claims := validClaims()
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			return signJWT(t, key, claims)
+		}(),
+		want: http.StatusUnauthorized,
+	}, {
+		name:  "valid",
+		token: signJWT(t, key, validClaims()),
+		want:  http.StatusOK,
+	}}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ri := RuntimeRequestWithExpectations(t, client, "http://"+name+".example.com",
+				[]ResponseExpectation{StatusCodeExpectation(sets.NewInt(tt.want))},
+				false,
+				func(r *http.Request) {
+					if tt.token != "" {
+						r.Header.Set("Authorization", "Bearer "+tt.token)
+					}
+				})
+			if tt.want != http.StatusOK {
+				return
+			}
+			if ri == nil {
+				t.Error("Couldn't make request")
+				return
+			}
+			if got, want := ri.Request.Headers.Get(claimHeader), subject; got != want {
+				t.Errorf("Header[%q] = %q, wanted %q", claimHeader, got, want)
+			}
+		})
+	}
+}
+
+func generateRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(cryptorand.Reader, 2048)
+	if err != nil {
+		t.Fatal("GenerateKey() =", err)
+	}
+	return key
+}
+
+// jwksFor returns a JSON Web Key Set document containing the given public key.
+func jwksFor(t *testing.T, pub *rsa.PublicKey) string {
+	t.Helper()
+	b, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": "conformance",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+	if err != nil {
+		t.Fatal("Marshal() =", err)
+	}
+	return string(b)
+}
+
+// signJWT returns a compact serialized RS256 JSON Web Token with the given claims.
+func signJWT(t *testing.T, key *rsa.PrivateKey, claims map[string]interface{}) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"kid": "conformance",
+	})
+	if err != nil {
+		t.Fatal("Marshal() =", err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal("Marshal() =", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	sig, err := rsa.SignPKCS1v15(cryptorand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal("SignPKCS1v15() =", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
@@ -238,3 +238,187 @@ func TestPathAndPercentageSplit(t *testing.T) {
 		}
 	}
 }
+
+// TestPathDefaultBackend verifies that an Ingress dispatches requests whose
+// path matches none of the paths of a rule to the rule's default backend, or
+// failing that, to the Ingress' default backend.
+func TestPathDefaultBackend(t *testing.T) {
+	t.Parallel()
+	clients := test.Setup(t)
+
+	// For /foo
+	fooName, fooPort, _ := CreateRuntimeService(t, clients, networking.ServicePortNameHTTP1)
+
+	// The default backend of the first rule.
+	ruleName, rulePort, _ := CreateRuntimeService(t, clients, networking.ServicePortNameHTTP1)
+
+	// The default backend of the Ingress.
+	specName, specPort, _ := CreateRuntimeService(t, clients, networking.ServicePortNameHTTP1)
+
+	withRuleDefault := ruleName + ".example.com"
+	withoutRuleDefault := specName + ".example.com"
+
+	paths := []v1alpha1.HTTPIngressPath{{
+		Path: "/foo",
+		Splits: []v1alpha1.IngressBackendSplit{{
+			IngressBackend: v1alpha1.IngressBackend{
+				ServiceName:      fooName,
+				ServiceNamespace: test.ServingNamespace,
+				ServicePort:      intstr.FromInt(fooPort),
+			},
+		}},
+	}}
+
+	_, client, _ := CreateIngressReady(t, clients, v1alpha1.IngressSpec{
+		Rules: []v1alpha1.IngressRule{{
+			Hosts:      []string{withRuleDefault},
+			Visibility: v1alpha1.IngressVisibilityExternalIP,
+			HTTP: &v1alpha1.HTTPIngressRuleValue{
+				Paths: paths,
+			},
+			DefaultBackend: &v1alpha1.IngressBackend{
+				ServiceName:      ruleName,
+				ServiceNamespace: test.ServingNamespace,
+				ServicePort:      intstr.FromInt(rulePort),
+			},
+		}, {
+			Hosts:      []string{withoutRuleDefault},
+			Visibility: v1alpha1.IngressVisibilityExternalIP,
+			HTTP: &v1alpha1.HTTPIngressRuleValue{
+				Paths: paths,
+			},
+		}},
+		DefaultBackend: &v1alpha1.IngressBackend{
+			ServiceName:      specName,
+			ServiceNamespace: test.ServingNamespace,
+			ServicePort:      intstr.FromInt(specPort),
+		},
+	})
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{{
+		name: "rule default backend, matching path",
+		url:  "http://" + withRuleDefault + "/foo",
+		want: fooName,
+	}, {
+		name: "rule default backend, root path",
+		url:  "http://" + withRuleDefault,
+		want: ruleName,
+	}, {
+		name: "rule default backend, unmatched path",
+		url:  "http://" + withRuleDefault + "/asdf",
+		want: ruleName,
+	}, {
+		name: "ingress default backend, matching path",
+		url:  "http://" + withoutRuleDefault + "/foo",
+		want: fooName,
+	}, {
+		name: "ingress default backend, unmatched path",
+		url:  "http://" + withoutRuleDefault + "/asdf",
+		want: specName,
+	}}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ri := RuntimeRequest(t, client, tt.url)
+			if ri == nil {
+				return
+			}
+
+			// The runtime Pods are named after their Services.
+			if got := ri.Host.EnvVars["HOSTNAME"]; got != tt.want {
+				t.Errorf("HOSTNAME = %q, wanted %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPathPrecedence verifies that an Ingress evaluates overlapping paths in
+// order of decreasing priority and then in declared order, regardless of the
+// length of the match.
+func TestPathPrecedence(t *testing.T) {
+	t.Parallel()
+	clients := test.Setup(t)
+
+	// For /foo
+	fooName, fooPort, _ := CreateRuntimeService(t, clients, networking.ServicePortNameHTTP1)
+
+	// For /foo/bar
+	barName, barPort, _ := CreateRuntimeService(t, clients, networking.ServicePortNameHTTP1)
+
+	// For /baz
+	bazName, bazPort, _ := CreateRuntimeService(t, clients, networking.ServicePortNameHTTP1)
+
+	// For /baz/qux
+	quxName, quxPort, _ := CreateRuntimeService(t, clients, networking.ServicePortNameHTTP1)
+
+	name, port, _ := CreateRuntimeService(t, clients, networking.ServicePortNameHTTP1)
+
+	// Use a post-split injected header to establish which split we are sending traffic to.
+	const headerName = "Which-Backend"
+
+	path := func(p string, priority int, name string, port int) v1alpha1.HTTPIngressPath {
+		return v1alpha1.HTTPIngressPath{
+			Path:     p,
+			Priority: priority,
+			Splits: []v1alpha1.IngressBackendSplit{{
+				IngressBackend: v1alpha1.IngressBackend{
+					ServiceName:      name,
+					ServiceNamespace: test.ServingNamespace,
+					ServicePort:      intstr.FromInt(port),
+				},
+				AppendHeaders: map[string]string{
+					headerName: name,
+				},
+				Percent: 100,
+			}},
+		}
+	}
+
+	_, client, _ := CreateIngressReady(t, clients, v1alpha1.IngressSpec{
+		Rules: []v1alpha1.IngressRule{{
+			Hosts:      []string{name + ".example.com"},
+			Visibility: v1alpha1.IngressVisibilityExternalIP,
+			HTTP: &v1alpha1.HTTPIngressRuleValue{
+				Paths: []v1alpha1.HTTPIngressPath{
+					// /foo/bar is shadowed by /foo, which is declared first.
+					path("/foo", 0, fooName, fooPort),
+					path("/foo/bar", 0, barName, barPort),
+					// /baz/qux is evaluated before /baz, which has a lower priority.
+					path("/baz", 0, bazName, bazPort),
+					path("/baz/qux", 1, quxName, quxPort),
+					path("", 0, name, port),
+				},
+			},
+		}},
+	})
+
+	tests := map[string]string{
+		"/foo":      fooName,
+		"/foo/bar":  fooName,
+		"/baz":      bazName,
+		"/baz/qux":  quxName,
+		"/baz/quux": bazName,
+		"/asdf":     name,
+	}
+
+	for path, want := range tests {
+		t.Run(path, func(t *testing.T) {
+			ri := RuntimeRequest(t, client, "http://"+name+".example.com"+path)
+			if ri == nil {
+				return
+			}
+
+			got := ri.Request.Headers.Get(headerName)
+			if got != want {
+				t.Errorf("Header[%q] = %q, wanted %q", headerName, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"net/http"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/networking/test"
+)
+
+// TestListenerPorts verifies that an Ingress serves the hosts of rules with a
+// custom port on that port, and only on that port.
+func TestListenerPorts(t *testing.T) {
+	t.Parallel()
+	clients := test.Setup(t)
+
+	name, port, _ := CreateRuntimeService(t, clients, networking.ServicePortNameHTTP1)
+
+	httpHost := name + "-http.example.com"
+	httpsHost := name + "-https.example.com"
+
+	secretName, _ := CreateTLSSecret(t, clients, []string{httpsHost})
+
+	paths := []v1alpha1.HTTPIngressPath{{
+		Splits: []v1alpha1.IngressBackendSplit{{
+			IngressBackend: v1alpha1.IngressBackend{
+				ServiceName:      name,
+				ServiceNamespace: test.ServingNamespace,
+				ServicePort:      intstr.FromInt(port),
+			},
+		}},
+	}}
+
+	// The client dials the port of each request's URL through the Ingress'
+	// public load balancer (see CreateDialContext).
+	_, client, _ := CreateIngressReady(t, clients, v1alpha1.IngressSpec{
+		Rules: []v1alpha1.IngressRule{{
+			Hosts:      []string{httpHost},
+			Visibility: v1alpha1.IngressVisibilityExternalIP,
+			Port:       9000,
+			Protocol:   v1alpha1.ListenerProtocolHTTP,
+			HTTP: &v1alpha1.HTTPIngressRuleValue{
+				Paths: paths,
+			},
+		}, {
+			Hosts:      []string{httpsHost},
+			Visibility: v1alpha1.IngressVisibilityExternalIP,
+			Port:       8443,
+			Protocol:   v1alpha1.ListenerProtocolHTTPS,
+			HTTP: &v1alpha1.HTTPIngressRuleValue{
+				Paths: paths,
+			},
+		}},
+		TLS: []v1alpha1.IngressTLS{{
+			Hosts:           []string{httpsHost},
+			SecretName:      secretName,
+			SecretNamespace: test.ServingNamespace,
+		}},
+	})
+
+	t.Run("verify HTTP on custom port", func(t *testing.T) {
+		RuntimeRequest(t, client, "http://"+httpHost+":9000")
+	})
+
+	t.Run("verify HTTPS on custom port", func(t *testing.T) {
+		RuntimeRequest(t, client, "https://"+httpsHost+":8443")
+	})
+
+	t.Run("verify standard port is not served", func(t *testing.T) {
+		RuntimeRequestWithExpectations(t, client, "http://"+httpHost,
+			[]ResponseExpectation{StatusCodeExpectation(sets.NewInt(http.StatusNotFound))},
+			false)
+	})
+}
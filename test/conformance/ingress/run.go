@@ -19,6 +19,10 @@ package ingress
 import (
 	"testing"
 
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/networking/pkg/ingress"
 	"knative.dev/networking/test"
 )
 
@@ -61,14 +65,57 @@ func RunConformance(t *testing.T) {
 	// ie. state - alpha, beta, ga
 	// ie. requirement - must, should, may
 
-	if test.ServingFlags.EnableBetaFeatures {
-		// Add your conformance test for beta features
-		t.Run("headers/probe", TestProbeHeaders)
+	// When the implementation publishes an IngressClass, its capabilities
+	// select the beta and alpha tests to run.  Otherwise fall back to the flags.
+	capabilities, published := ingressClassCapabilities(t)
+	for _, ct := range betaTests {
+		if published && capabilities.Has(ct.capability) || !published && test.ServingFlags.EnableBetaFeatures {
+			t.Run(ct.name, ct.test)
+		}
 	}
+	for _, ct := range alphaTests {
+		if published && capabilities.Has(ct.capability) || !published && test.ServingFlags.EnableAlphaFeatures {
+			t.Run(ct.name, ct.test)
+		}
+	}
+}
+
+type conformanceTest struct {
+	name       string
+	capability string
+	test       func(*testing.T)
+}
+
+// Add your conformance test for beta features
+var betaTests = []conformanceTest{
+	{"headers/probe", ingress.FeatureProbeHeaders, TestProbeHeaders},
+}
+
+// Add your conformance test for alpha features
+var alphaTests = []conformanceTest{
+	{"headers/tags", ingress.FeatureTagHeaders, TestTagHeaders},
+	{"host-rewrite", ingress.FeatureRewriteHost, TestRewriteHost},
+	{"grpc/protocol", ingress.FeatureBackendProtocol, TestGRPCProtocol},
+	{"websocket/protocol", ingress.FeatureBackendProtocol, TestWebsocketProtocol},
+	{"jwt", ingress.FeatureJWT, TestJWT},
+	{"ext-authz", ingress.FeatureExtAuthz, TestExtAuthz},
+	{"source-ranges", ingress.FeatureSourceRanges, TestSourceRanges},
+	{"compression", ingress.FeatureCompression, TestCompression},
+	{"error-responses", ingress.FeatureErrorResponses, TestErrorResponses},
+	{"dispatch/path/default-backend", ingress.FeatureDefaultBackend, TestPathDefaultBackend},
+	{"dispatch/path/precedence", ingress.FeaturePathPriority, TestPathPrecedence},
+	{"ports", ingress.FeatureListenerPort, TestListenerPorts},
+}
 
-	if test.ServingFlags.EnableAlphaFeatures {
-		// Add your conformance test for alpha features
-		t.Run("headers/tags", TestTagHeaders)
-		t.Run("host-rewrite", TestRewriteHost)
+// ingressClassCapabilities returns the capabilities of the IngressClass of the
+// implementation under test, and whether it publishes one.
+func ingressClassCapabilities(t *testing.T) (sets.String, bool) {
+	clients := test.Setup(t)
+	class, err := clients.NetworkingClient.IngressClasses.Get(test.ServingFlags.IngressClass, metav1.GetOptions{})
+	if apierrs.IsNotFound(err) {
+		return nil, false
+	} else if err != nil {
+		t.Fatalf("Error fetching IngressClass %q: %v", test.ServingFlags.IngressClass, err)
 	}
+	return sets.NewString(class.Spec.Capabilities...), true
 }
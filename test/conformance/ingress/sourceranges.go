@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/networking/pkg/apis/networking"
+	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/networking/test"
+)
+
+// TestSourceRanges verifies that an Ingress admits or rejects requests based
+// on the source address of the test client.
+func TestSourceRanges(t *testing.T) {
+	t.Parallel()
+	clients := test.Setup(t)
+
+	name, port, _ := CreateRuntimeService(t, clients, networking.ServicePortNameHTTP1)
+
+	// We don't know the address the gateway sees for the test client, so we use
+	// ranges that either match every address or none that can reach us.
+	var (
+		everything = []string{"0.0.0.0/0", "::/0"}
+		// TEST-NET-1 and the IPv6 documentation prefix are never routed.
+		documentation = []string{"192.0.2.0/24", "2001:db8::/32"}
+	)
+
+	tests := []struct {
+		name    string
+		allowed []string
+		denied  []string
+		want    int
+	}{{
+		name:    "allowed",
+		allowed: everything,
+		want:    http.StatusOK,
+	}, {
+		name:    "not allowed",
+		allowed: documentation,
+		want:    http.StatusForbidden,
+	}, {
+		name:   "denied",
+		denied: everything,
+		want:   http.StatusForbidden,
+	}, {
+		name:   "not denied",
+		denied: documentation,
+		want:   http.StatusOK,
+	}, {
+		name:    "denied takes precedence",
+		allowed: everything,
+		denied:  everything,
+		want:    http.StatusForbidden,
+	}}
+
+	rules := make([]v1alpha1.IngressRule, 0, len(tests))
+	hosts := make([]string, 0, len(tests))
+	for i, tt := range tests {
+		hosts = append(hosts, fmt.Sprintf("%s-%d.example.com", name, i))
+		rules = append(rules, v1alpha1.IngressRule{
+			Hosts:               []string{hosts[i]},
+			Visibility:          v1alpha1.IngressVisibilityExternalIP,
+			AllowedSourceRanges: tt.allowed,
+			DeniedSourceRanges:  tt.denied,
+			HTTP: &v1alpha1.HTTPIngressRuleValue{
+				Paths: []v1alpha1.HTTPIngressPath{{
+					Splits: []v1alpha1.IngressBackendSplit{{
+						IngressBackend: v1alpha1.IngressBackend{
+							ServiceName:      name,
+							ServiceNamespace: test.ServingNamespace,
+							ServicePort:      intstr.FromInt(port),
+						},
+					}},
+				}},
+			},
+		})
+	}
+
+	// Create a single Ingress with a rule for each case.
+	_, client, _ := CreateIngressReady(t, clients, v1alpha1.IngressSpec{
+		Rules: rules,
+	})
+
+	for i, tt := range tests {
+		host := hosts[i]
+		want := tt.want
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			RuntimeRequestWithExpectations(t, client, "http://"+host,
+				[]ResponseExpectation{StatusCodeExpectation(sets.NewInt(want))},
+				false)
+		})
+	}
+}
@@ -298,6 +298,80 @@ func CreateTimeoutService(t *testing.T, clients *test.Clients) (string, int, con
 	return name, port, createPodAndService(t, clients, pod, svc)
 }
 
+// CreateAuthzService creates a Kubernetes service that implements a trivial
+// external authorization server, allowing requests that carry the header
+// `X-Authz-Decision: allow`.  It returns the service name, the port on which
+// the service is listening, and a "cancel" function to clean up the created
+// resources.
+func CreateAuthzService(t *testing.T, clients *test.Clients) (string, int, context.CancelFunc) {
+	t.Helper()
+	name := test.ObjectNameForTest(t)
+
+	// Avoid zero, but pick a low port number.
+	port := 50 + rand.Intn(50)
+	t.Logf("[%s] Using port %d", name, port)
+
+	// Pick a high port number.
+	containerPort := 8000 + rand.Intn(100)
+	t.Logf("[%s] Using containerPort %d", name, containerPort)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: test.ServingNamespace,
+			Labels: map[string]string{
+				"test-pod": name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:            "foo",
+				Image:           pkgTest.ImagePath("authz"),
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Ports: []corev1.ContainerPort{{
+					Name:          networking.ServicePortNameHTTP1,
+					ContainerPort: int32(containerPort),
+				}},
+				// This is needed by the authz image we are using.
+				Env: []corev1.EnvVar{{
+					Name:  "PORT",
+					Value: strconv.Itoa(containerPort),
+				}},
+				ReadinessProbe: &corev1.Probe{
+					Handler: corev1.Handler{
+						TCPSocket: &corev1.TCPSocketAction{
+							Port: intstr.FromInt(containerPort),
+						},
+					},
+				},
+			}},
+		},
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: test.ServingNamespace,
+			Labels: map[string]string{
+				"test-pod": name,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: "ClusterIP",
+			Ports: []corev1.ServicePort{{
+				Name:       networking.ServicePortNameHTTP1,
+				Port:       int32(port),
+				TargetPort: intstr.FromInt(containerPort),
+			}},
+			Selector: map[string]string{
+				"test-pod": name,
+			},
+		},
+	}
+
+	return name, port, createPodAndService(t, clients, pod, svc)
+}
+
 // CreateFlakyService creates a Kubernetes service where the backing pod will
 // succeed only every Nth request.
 func CreateFlakyService(t *testing.T, clients *test.Clients, period int) (string, int, context.CancelFunc) {
@@ -373,9 +447,95 @@ func CreateFlakyService(t *testing.T, clients *test.Clients, period int) (string
 	return name, port, createPodAndService(t, clients, pod, svc)
 }
 
+// CreateFailingService creates a Kubernetes service whose backing pod crashes
+// shortly after starting and never becomes ready, so the service never has
+// any ready endpoints.  It returns the service name, the port on which the
+// service is listening, and a "cancel" function to clean up the created
+// resources.
+func CreateFailingService(t *testing.T, clients *test.Clients) (string, int, context.CancelFunc) {
+	t.Helper()
+	name := test.ObjectNameForTest(t)
+
+	// Avoid zero, but pick a low port number.
+	port := 50 + rand.Intn(50)
+	t.Logf("[%s] Using port %d", name, port)
+
+	// Pick a high port number.
+	containerPort := 8000 + rand.Intn(100)
+	t.Logf("[%s] Using containerPort %d", name, containerPort)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: test.ServingNamespace,
+			Labels: map[string]string{
+				"test-pod": name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:            "foo",
+				Image:           pkgTest.ImagePath("failing"),
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Ports: []corev1.ContainerPort{{
+					Name:          networking.ServicePortNameHTTP1,
+					ContainerPort: int32(containerPort),
+				}},
+				// The failing image never listens, so this keeps the pod
+				// from ever becoming ready.
+				ReadinessProbe: &corev1.Probe{
+					Handler: corev1.Handler{
+						TCPSocket: &corev1.TCPSocketAction{
+							Port: intstr.FromInt(containerPort),
+						},
+					},
+				},
+			}},
+		},
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: test.ServingNamespace,
+			Labels: map[string]string{
+				"test-pod": name,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: "ClusterIP",
+			Ports: []corev1.ServicePort{{
+				Name:       networking.ServicePortNameHTTP1,
+				Port:       int32(port),
+				TargetPort: intstr.FromInt(containerPort),
+			}},
+			Selector: map[string]string{
+				"test-pod": name,
+			},
+		},
+	}
+
+	// Unlike createPodAndService, don't wait for the pod to show up in the
+	// Endpoints resource, as it never will.
+	podCancel := createPod(t, clients, pod)
+	svcCancel := createService(t, clients, svc)
+
+	return name, port, func() {
+		svcCancel()
+		podCancel()
+	}
+}
+
 // CreateWebsocketService creates a Kubernetes service that will upgrade the connection
 // to use websockets and echo back the received messages with the provided suffix.
 func CreateWebsocketService(t *testing.T, clients *test.Clients, suffix string) (string, int, context.CancelFunc) {
+	t.Helper()
+	return createWebsocketService(t, clients, suffix, networking.ServicePortNameHTTP1)
+}
+
+// createWebsocketService is a helper for creating a websocket service whose
+// port is called portName.
+func createWebsocketService(t *testing.T, clients *test.Clients, suffix, portName string) (string, int, context.CancelFunc) {
 	t.Helper()
 	name := test.ObjectNameForTest(t)
 
@@ -401,7 +561,7 @@ func CreateWebsocketService(t *testing.T, clients *test.Clients, suffix string)
 				Image:           pkgTest.ImagePath("wsserver"),
 				ImagePullPolicy: corev1.PullIfNotPresent,
 				Ports: []corev1.ContainerPort{{
-					Name:          networking.ServicePortNameHTTP1,
+					Name:          portName,
 					ContainerPort: int32(containerPort),
 				}},
 				// This is needed by the runtime image we are using.
@@ -435,7 +595,7 @@ func CreateWebsocketService(t *testing.T, clients *test.Clients, suffix string)
 		Spec: corev1.ServiceSpec{
 			Type: "ClusterIP",
 			Ports: []corev1.ServicePort{{
-				Name:       networking.ServicePortNameHTTP1,
+				Name:       portName,
 				Port:       int32(port),
 				TargetPort: intstr.FromInt(containerPort),
 			}},
@@ -451,6 +611,13 @@ func CreateWebsocketService(t *testing.T, clients *test.Clients, suffix string)
 // CreateGRPCService creates a Kubernetes service that will upgrade the connection
 // to use GRPC and echo back the received messages with the provided suffix.
 func CreateGRPCService(t *testing.T, clients *test.Clients, suffix string) (string, int, context.CancelFunc) {
+	t.Helper()
+	return createGRPCService(t, clients, suffix, networking.ServicePortNameH2C)
+}
+
+// createGRPCService is a helper for creating a GRPC service whose port is
+// called portName.
+func createGRPCService(t *testing.T, clients *test.Clients, suffix, portName string) (string, int, context.CancelFunc) {
 	t.Helper()
 	name := test.ObjectNameForTest(t)
 
@@ -476,7 +643,7 @@ func CreateGRPCService(t *testing.T, clients *test.Clients, suffix string) (stri
 				Image:           pkgTest.ImagePath("grpc-ping"),
 				ImagePullPolicy: corev1.PullIfNotPresent,
 				Ports: []corev1.ContainerPort{{
-					Name:          networking.ServicePortNameH2C,
+					Name:          portName,
 					ContainerPort: int32(containerPort),
 				}},
 				// This is needed by the runtime image we are using.
@@ -509,7 +676,7 @@ func CreateGRPCService(t *testing.T, clients *test.Clients, suffix string) (stri
 		Spec: corev1.ServiceSpec{
 			Type: "ClusterIP",
 			Ports: []corev1.ServicePort{{
-				Name:       networking.ServicePortNameH2C,
+				Name:       portName,
 				Port:       int32(port),
 				TargetPort: intstr.FromInt(containerPort),
 			}},
@@ -544,6 +711,27 @@ func createService(t *testing.T, clients *test.Clients, svc *corev1.Service) con
 	}
 }
 
+// createPod is a helper for creating the pod resource and setting up its
+// context.CancelFunc.
+func createPod(t *testing.T, clients *test.Clients, pod *corev1.Pod) context.CancelFunc {
+	t.Helper()
+
+	t.Cleanup(func() { clients.KubeClient.Kube.CoreV1().Pods(pod.Namespace).Delete(pod.Name, &metav1.DeleteOptions{}) })
+	if err := reconciler.RetryTestErrors(func(attempts int) error {
+		_, err := clients.KubeClient.Kube.CoreV1().Pods(pod.Namespace).Create(pod)
+		return err
+	}); err != nil {
+		t.Fatal("Error creating Pod:", err)
+	}
+
+	return func() {
+		err := clients.KubeClient.Kube.CoreV1().Pods(pod.Namespace).Delete(pod.Name, &metav1.DeleteOptions{})
+		if err != nil {
+			t.Errorf("Error cleaning up Pod %s: %v", pod.Name, err)
+		}
+	}
+}
+
 func createExternalNameService(t *testing.T, clients *test.Clients, target, gatewayDomain string) context.CancelFunc {
 	targetName := strings.SplitN(target, ".", 3)
 	externalNameSvc := &corev1.Service{
@@ -29,6 +29,7 @@ import (
 	"github.com/gorilla/websocket"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/networking/pkg/apis/networking"
 	"knative.dev/networking/pkg/apis/networking/v1alpha1"
 	"knative.dev/networking/test"
 )
@@ -80,6 +81,56 @@ func TestWebsocket(t *testing.T) {
 	}
 }
 
+// TestWebsocketProtocol verifies that websockets may be used via an Ingress whose
+// backend declares its protocol explicitly, rather than relying on the port name.
+func TestWebsocketProtocol(t *testing.T) {
+	t.Parallel()
+	clients := test.Setup(t)
+
+	const suffix = "- pong"
+	// Deliberately not named http, so the port name does not hint at the protocol.
+	name, port, _ := createWebsocketService(t, clients, suffix, "websocket")
+
+	domain := name + ".example.com"
+
+	// Create a simple Ingress over the Service.
+	_, dialCtx, _ := CreateIngressReadyDialContext(t, clients, v1alpha1.IngressSpec{
+		Rules: []v1alpha1.IngressRule{{
+			Hosts:      []string{domain},
+			Visibility: v1alpha1.IngressVisibilityExternalIP,
+			HTTP: &v1alpha1.HTTPIngressRuleValue{
+				Paths: []v1alpha1.HTTPIngressPath{{
+					Splits: []v1alpha1.IngressBackendSplit{{
+						IngressBackend: v1alpha1.IngressBackend{
+							ServiceName:      name,
+							ServiceNamespace: test.ServingNamespace,
+							ServicePort:      intstr.FromInt(port),
+							Protocol:         networking.ProtocolHTTP1,
+						},
+					}},
+				}},
+			},
+		}},
+	})
+
+	dialer := websocket.Dialer{
+		NetDialContext:   dialCtx,
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 45 * time.Second,
+	}
+
+	u := url.URL{Scheme: "ws", Host: domain, Path: "/"}
+	conn, _, err := dialer.Dial(u.String(), http.Header{"Host": {domain}})
+	if err != nil {
+		t.Fatal("Dial() =", err)
+	}
+	defer conn.Close()
+
+	for i := 0; i < 100; i++ {
+		checkWebsocketRoundTrip(t, conn, suffix)
+	}
+}
+
 // TestWebsocketSplit verifies that websockets may be used across a traffic split.
 func TestWebsocketSplit(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,51 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+
+	"knative.dev/networking/test"
+)
+
+const (
+	// decisionHeader is the request header that carries the decision the
+	// caller wants us to make.
+	decisionHeader = "X-Authz-Decision"
+	// userHeader is the response header we set on allowed requests, so
+	// tests can check it is forwarded upstream.
+	userHeader = "X-Authz-User"
+)
+
+// handler implements a trivial external authorization server: requests are
+// allowed if and only if they carry `X-Authz-Decision: allow`.
+func handler(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(decisionHeader) != "allow" {
+		log.Printf("Denying request to %s", r.URL.Path)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	log.Printf("Allowing request to %s", r.URL.Path)
+	w.Header().Set(userHeader, "conformance")
+	w.WriteHeader(http.StatusOK)
+}
+
+func main() {
+	test.ListenAndServeGracefully(":"+os.Getenv("PORT"), handler)
+}
@@ -29,6 +29,7 @@ import (
 // InitHandlers initializes all handlers.
 func InitHandlers(mux *http.ServeMux) {
 	mux.HandleFunc("/", withHeaders(withRequestLog(runtimeHandler)))
+	mux.HandleFunc(LargePath, withHeaders(largeHandler))
 
 	h := network.NewProbeHandler(withRequestLog(withKubeletProbeHeaderCheck))
 	mux.HandleFunc(network.ProbePath, h.ServeHTTP)
@@ -0,0 +1,54 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+)
+
+// LargePath is the path of the handler returning a response body of
+// the size given by the `bytes` query parameter, up to MaxLargeBytes, and of
+// the content type given by the optional `contentType` query parameter.
+const LargePath = "/large"
+
+// MaxLargeBytes bounds the size of the bodies returned by the LargePath
+// handler, so that a request can't make the test image allocate arbitrary
+// amounts of memory.
+const MaxLargeBytes = 10 << 20
+
+// largeHandler writes a highly compressible body of the requested size,
+// clamped to MaxLargeBytes, and content type, text/plain by default.
+func largeHandler(w http.ResponseWriter, r *http.Request) {
+	size, err := strconv.Atoi(r.URL.Query().Get("bytes"))
+	if err != nil || size < 0 {
+		http.Error(w, "bytes must be a non-negative integer", http.StatusBadRequest)
+		return
+	}
+	if size > MaxLargeBytes {
+		size = MaxLargeBytes
+	}
+	contentType := r.URL.Query().Get("contentType")
+	if contentType == "" {
+		contentType = "text/plain; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(size))
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes.Repeat([]byte("a"), size))
+}